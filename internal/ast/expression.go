@@ -36,6 +36,39 @@ func (n *PrefixExpression) String() string {
 	return out.String()
 }
 
+func (n *PrefixExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type UpdateExpression struct {
+	expression
+	Token   token.Token
+	Operand Expression
+	Prefix  bool
+}
+
+func NewUpdateExpression(token token.Token, operand Expression, prefix bool) *UpdateExpression {
+	return &UpdateExpression{Token: token, Operand: operand, Prefix: prefix}
+}
+
+func (n *UpdateExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	if n.Prefix {
+		out.WriteString(n.Token.Literal)
+		out.WriteString(n.Operand.String())
+	} else {
+		out.WriteString(n.Operand.String())
+		out.WriteString(n.Token.Literal)
+	}
+	out.WriteString(")")
+	return out.String()
+}
+
+func (n *UpdateExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
 type InfixExpression struct {
 	expression
 	Token token.Token
@@ -57,6 +90,89 @@ func (n *InfixExpression) String() string {
 	return out.String()
 }
 
+func (n *InfixExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type LogicalExpression struct {
+	expression
+	Token token.Token
+	Left  Expression
+	Right Expression
+}
+
+func NewLogicalExpression(token token.Token, left, right Expression) *LogicalExpression {
+	return &LogicalExpression{Token: token, Left: left, Right: right}
+}
+
+func (n *LogicalExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	out.WriteString(n.Left.String())
+	out.WriteString(n.Token.Literal)
+	out.WriteString(n.Right.String())
+	out.WriteString(")")
+	return out.String()
+}
+
+func (n *LogicalExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type ConditionalExpression struct {
+	expression
+	Token       token.Token
+	Test        Expression
+	Consequent  Expression
+	Alternative Expression
+}
+
+func NewConditionalExpression(token token.Token, test, consequent, alternative Expression) *ConditionalExpression {
+	return &ConditionalExpression{Token: token, Test: test, Consequent: consequent, Alternative: alternative}
+}
+
+func (n *ConditionalExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	out.WriteString(n.Test.String())
+	out.WriteString(" ? ")
+	out.WriteString(n.Consequent.String())
+	out.WriteString(" : ")
+	out.WriteString(n.Alternative.String())
+	out.WriteString(")")
+	return out.String()
+}
+
+func (n *ConditionalExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type SequenceExpression struct {
+	expression
+	Expressions []Expression
+}
+
+func NewSequenceExpression(expressions ...Expression) *SequenceExpression {
+	return &SequenceExpression{Expressions: expressions}
+}
+
+func (n *SequenceExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	for i, exp := range n.Expressions {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(exp.String())
+	}
+	out.WriteString(")")
+	return out.String()
+}
+
+func (n *SequenceExpression) Pos() token.Position {
+	return token.Position{}
+}
+
 type AssignmentExpression struct {
 	expression
 	Token token.Token
@@ -75,3 +191,392 @@ func (n *AssignmentExpression) String() string {
 	out.WriteString(n.Right.String())
 	return out.String()
 }
+
+func (n *AssignmentExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type MemberExpression struct {
+	expression
+	Token    token.Token
+	Object   Expression
+	Property Expression
+	Computed bool
+	Optional bool
+}
+
+func NewMemberExpression(token token.Token, object, property Expression, computed, optional bool) *MemberExpression {
+	return &MemberExpression{Token: token, Object: object, Property: property, Computed: computed, Optional: optional}
+}
+
+func (n *MemberExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Object.String())
+	if n.Optional {
+		out.WriteString("?.")
+	}
+	if n.Computed {
+		out.WriteString("[")
+		out.WriteString(n.Property.String())
+		out.WriteString("]")
+	} else {
+		if !n.Optional {
+			out.WriteString(".")
+		}
+		out.WriteString(n.Property.String())
+	}
+	return out.String()
+}
+
+func (n *MemberExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type CallExpression struct {
+	expression
+	Token     token.Token
+	Callee    Expression
+	Arguments []Expression
+	Optional  bool
+}
+
+func NewCallExpression(token token.Token, callee Expression, arguments []Expression, optional bool) *CallExpression {
+	return &CallExpression{Token: token, Callee: callee, Arguments: arguments, Optional: optional}
+}
+
+func (n *CallExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Callee.String())
+	if n.Optional {
+		out.WriteString("?.")
+	}
+	out.WriteString("(")
+	for i, arg := range n.Arguments {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(arg.String())
+	}
+	out.WriteString(")")
+	return out.String()
+}
+
+func (n *CallExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type NewExpression struct {
+	expression
+	Token     token.Token
+	Callee    Expression
+	Arguments []Expression
+}
+
+func NewNewExpression(token token.Token, callee Expression, arguments []Expression) *NewExpression {
+	return &NewExpression{Token: token, Callee: callee, Arguments: arguments}
+}
+
+func (n *NewExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("new ")
+	out.WriteString(n.Callee.String())
+	out.WriteString("(")
+	for i, arg := range n.Arguments {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(arg.String())
+	}
+	out.WriteString(")")
+	return out.String()
+}
+
+func (n *NewExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+// ChainExpression delimits the member and call expressions skipped when an optional link short-circuits.
+type ChainExpression struct {
+	expression
+	Expression Expression
+}
+
+func NewChainExpression(expression Expression) *ChainExpression {
+	return &ChainExpression{Expression: expression}
+}
+
+func (n *ChainExpression) String() string {
+	return n.Expression.String()
+}
+
+func (n *ChainExpression) Pos() token.Position {
+	return token.Position{}
+}
+
+type ThisExpression struct {
+	expression
+	Token token.Token
+}
+
+func NewThisExpression(token token.Token) *ThisExpression {
+	return &ThisExpression{Token: token}
+}
+
+func (n *ThisExpression) String() string {
+	return n.Token.Literal
+}
+
+func (n *ThisExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type FunctionExpression struct {
+	expression
+	Token      token.Token
+	Name       *IdentifierLiteral
+	Parameters []Expression
+	Body       *BlockStatement
+}
+
+func NewFunctionExpression(token token.Token, name *IdentifierLiteral, parameters []Expression, body *BlockStatement) *FunctionExpression {
+	return &FunctionExpression{Token: token, Name: name, Parameters: parameters, Body: body}
+}
+
+func (n *FunctionExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Token.Literal)
+	if n.Name != nil {
+		out.WriteString(" ")
+		out.WriteString(n.Name.String())
+	}
+	out.WriteString("(")
+	for i, param := range n.Parameters {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(param.String())
+	}
+	out.WriteString(") ")
+	out.WriteString(n.Body.String())
+	return out.String()
+}
+
+func (n *FunctionExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+// ArrowFunctionExpression is a function without this and arguments of its own, whose body is either a block or an expression.
+type ArrowFunctionExpression struct {
+	expression
+	Token      token.Token
+	Parameters []Expression
+	Body       Node
+}
+
+func NewArrowFunctionExpression(token token.Token, parameters []Expression, body Node) *ArrowFunctionExpression {
+	return &ArrowFunctionExpression{Token: token, Parameters: parameters, Body: body}
+}
+
+func (n *ArrowFunctionExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	for i, param := range n.Parameters {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(param.String())
+	}
+	out.WriteString(") ")
+	out.WriteString(n.Token.Literal)
+	out.WriteString(" ")
+	out.WriteString(n.Body.String())
+	return out.String()
+}
+
+func (n *ArrowFunctionExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+// SpreadElement expands an iterable into the arguments of a call.
+type SpreadElement struct {
+	expression
+	Token    token.Token
+	Argument Expression
+}
+
+func NewSpreadElement(token token.Token, argument Expression) *SpreadElement {
+	return &SpreadElement{Token: token, Argument: argument}
+}
+
+func (n *SpreadElement) String() string {
+	return n.Token.Literal + n.Argument.String()
+}
+
+func (n *SpreadElement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+// RestElement collects the remaining arguments of a call into the parameter.
+type RestElement struct {
+	expression
+	Token    token.Token
+	Argument *IdentifierLiteral
+}
+
+func NewRestElement(token token.Token, argument *IdentifierLiteral) *RestElement {
+	return &RestElement{Token: token, Argument: argument}
+}
+
+func (n *RestElement) String() string {
+	return n.Token.Literal + n.Argument.String()
+}
+
+func (n *RestElement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+// SuperExpression refers to the parent class, either to call its constructor or to access its prototype.
+type SuperExpression struct {
+	expression
+	Token token.Token
+}
+
+func NewSuperExpression(token token.Token) *SuperExpression {
+	return &SuperExpression{Token: token}
+}
+
+func (n *SuperExpression) String() string {
+	return n.Token.Literal
+}
+
+func (n *SuperExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+// ClassExpression is a class, whose members are either methods or fields.
+type ClassExpression struct {
+	expression
+	Token      token.Token
+	Name       *IdentifierLiteral
+	SuperClass Expression
+	Members    []Node
+}
+
+func NewClassExpression(token token.Token, name *IdentifierLiteral, superClass Expression, members []Node) *ClassExpression {
+	return &ClassExpression{Token: token, Name: name, SuperClass: superClass, Members: members}
+}
+
+func (n *ClassExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Token.Literal)
+	if n.Name != nil {
+		out.WriteString(" ")
+		out.WriteString(n.Name.String())
+	}
+	if n.SuperClass != nil {
+		out.WriteString(" extends ")
+		out.WriteString(n.SuperClass.String())
+	}
+	out.WriteString(" {")
+	for _, member := range n.Members {
+		out.WriteString(" ")
+		out.WriteString(member.String())
+	}
+	out.WriteString(" }")
+	return out.String()
+}
+
+func (n *ClassExpression) Pos() token.Position {
+	return n.Token.Pos
+}
+
+// The kinds of methods a class defines.
+const (
+	CONSTRUCTOR = "constructor"
+	METHOD      = "method"
+	GET         = "get"
+	SET         = "set"
+)
+
+// MethodDefinition is a method of a class, which is a getter or a setter of the key by its kind.
+type MethodDefinition struct {
+	Key      Expression
+	Value    *FunctionExpression
+	Kind     string
+	Computed bool
+	Static   bool
+}
+
+func NewMethodDefinition(key Expression, value *FunctionExpression, kind string, computed, static bool) *MethodDefinition {
+	return &MethodDefinition{Key: key, Value: value, Kind: kind, Computed: computed, Static: static}
+}
+
+func (n *MethodDefinition) String() string {
+	var out bytes.Buffer
+	if n.Static {
+		out.WriteString("static ")
+	}
+	if n.Kind == GET || n.Kind == SET {
+		out.WriteString(n.Kind)
+		out.WriteString(" ")
+	}
+	if n.Computed {
+		out.WriteString("[")
+		out.WriteString(n.Key.String())
+		out.WriteString("]")
+	} else {
+		out.WriteString(n.Key.String())
+	}
+	out.WriteString("(")
+	for i, param := range n.Value.Parameters {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(param.String())
+	}
+	out.WriteString(") ")
+	out.WriteString(n.Value.Body.String())
+	return out.String()
+}
+
+func (n *MethodDefinition) Pos() token.Position {
+	return token.Position{}
+}
+
+// PropertyDefinition is a field of a class, which evaluates to defining the key on this with the value.
+// Fields other than static ones are evaluated for each instance as it is constructed.
+type PropertyDefinition struct {
+	expression
+	Key      Expression
+	Value    Expression
+	Computed bool
+	Static   bool
+}
+
+func NewPropertyDefinition(key, value Expression, computed, static bool) *PropertyDefinition {
+	return &PropertyDefinition{Key: key, Value: value, Computed: computed, Static: static}
+}
+
+func (n *PropertyDefinition) String() string {
+	var out bytes.Buffer
+	if n.Static {
+		out.WriteString("static ")
+	}
+	if n.Computed {
+		out.WriteString("[")
+		out.WriteString(n.Key.String())
+		out.WriteString("]")
+	} else {
+		out.WriteString(n.Key.String())
+	}
+	if n.Value != nil {
+		out.WriteString(" = ")
+		out.WriteString(n.Value.String())
+	}
+	out.WriteString(";")
+	return out.String()
+}
+
+func (n *PropertyDefinition) Pos() token.Position {
+	return token.Position{}
+}
@@ -1,6 +1,8 @@
 package ast
 
 import (
+	"bytes"
+
 	"github.com/siyul-park/minijs/internal/token"
 )
 
@@ -17,6 +19,10 @@ func (n *NullLiteral) String() string {
 	return n.Token.Literal
 }
 
+func (n *NullLiteral) Pos() token.Position {
+	return n.Token.Pos
+}
+
 type UndefinedLiteral struct {
 	expression
 	Token token.Token
@@ -30,6 +36,10 @@ func (n *UndefinedLiteral) String() string {
 	return n.Token.Literal
 }
 
+func (n *UndefinedLiteral) Pos() token.Position {
+	return n.Token.Pos
+}
+
 type BoolLiteral struct {
 	expression
 	Token token.Token
@@ -44,6 +54,10 @@ func (n *BoolLiteral) String() string {
 	return n.Token.Literal
 }
 
+func (n *BoolLiteral) Pos() token.Position {
+	return n.Token.Pos
+}
+
 type NumberLiteral struct {
 	expression
 	Token token.Token
@@ -58,6 +72,10 @@ func (n *NumberLiteral) String() string {
 	return n.Token.Literal
 }
 
+func (n *NumberLiteral) Pos() token.Position {
+	return n.Token.Pos
+}
+
 type StringLiteral struct {
 	expression
 	Token token.Token
@@ -72,6 +90,10 @@ func (n *StringLiteral) String() string {
 	return "\"" + n.Token.Literal + "\""
 }
 
+func (n *StringLiteral) Pos() token.Position {
+	return n.Token.Pos
+}
+
 type IdentifierLiteral struct {
 	expression
 	Token token.Token
@@ -85,3 +107,142 @@ func NewIdentifierLiteral(tok token.Token, value string) *IdentifierLiteral {
 func (n *IdentifierLiteral) String() string {
 	return n.Value
 }
+
+func (n *IdentifierLiteral) Pos() token.Position {
+	return n.Token.Pos
+}
+
+// PrivateIdentifier names a private member of a class, whose value keeps the leading '#'.
+type PrivateIdentifier struct {
+	expression
+	Token token.Token
+	Value string
+}
+
+func NewPrivateIdentifier(tok token.Token, value string) *PrivateIdentifier {
+	return &PrivateIdentifier{Token: tok, Value: value}
+}
+
+func (n *PrivateIdentifier) String() string {
+	return n.Value
+}
+
+func (n *PrivateIdentifier) Pos() token.Position {
+	return n.Token.Pos
+}
+
+// ArrayLiteral creates an array of the elements, where nil elements are holes.
+type ArrayLiteral struct {
+	expression
+	Token    token.Token
+	Elements []Expression
+}
+
+func NewArrayLiteral(tok token.Token, elements ...Expression) *ArrayLiteral {
+	return &ArrayLiteral{Token: tok, Elements: elements}
+}
+
+func (n *ArrayLiteral) String() string {
+	var out bytes.Buffer
+	out.WriteString("[")
+	for i, elem := range n.Elements {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		if elem != nil {
+			out.WriteString(elem.String())
+		}
+	}
+	if len(n.Elements) > 0 && n.Elements[len(n.Elements)-1] == nil {
+		out.WriteString(",")
+	}
+	out.WriteString("]")
+	return out.String()
+}
+
+func (n *ArrayLiteral) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type ObjectLiteral struct {
+	expression
+	Token      token.Token
+	Properties []*Property
+}
+
+func NewObjectLiteral(tok token.Token, properties ...*Property) *ObjectLiteral {
+	return &ObjectLiteral{Token: tok, Properties: properties}
+}
+
+func (n *ObjectLiteral) String() string {
+	var out bytes.Buffer
+	out.WriteString("{")
+	for i, prop := range n.Properties {
+		if i > 0 {
+			out.WriteString(", ")
+		}
+		out.WriteString(prop.String())
+	}
+	out.WriteString("}")
+	return out.String()
+}
+
+func (n *ObjectLiteral) Pos() token.Position {
+	return n.Token.Pos
+}
+
+// INIT is the kind of properties of object literals initialized with their values, while accessors are
+// of the kinds of the methods defining them.
+const INIT = "init"
+
+// Property is a property of an object literal, whose key is evaluated only when computed.
+type Property struct {
+	Key       Expression
+	Value     Expression
+	Kind      string
+	Computed  bool
+	Shorthand bool
+	Method    bool
+}
+
+func NewProperty(key, value Expression, kind string, computed, shorthand, method bool) *Property {
+	return &Property{Key: key, Value: value, Kind: kind, Computed: computed, Shorthand: shorthand, Method: method}
+}
+
+func (n *Property) String() string {
+	if n.Shorthand {
+		return n.Key.String()
+	}
+
+	var out bytes.Buffer
+	if n.Kind == GET || n.Kind == SET {
+		out.WriteString(n.Kind)
+		out.WriteString(" ")
+	}
+	if n.Computed {
+		out.WriteString("[")
+		out.WriteString(n.Key.String())
+		out.WriteString("]")
+	} else {
+		out.WriteString(n.Key.String())
+	}
+	if fn, ok := n.Value.(*FunctionExpression); ok && n.Method {
+		out.WriteString("(")
+		for i, param := range fn.Parameters {
+			if i > 0 {
+				out.WriteString(", ")
+			}
+			out.WriteString(param.String())
+		}
+		out.WriteString(") ")
+		out.WriteString(fn.Body.String())
+		return out.String()
+	}
+	out.WriteString(": ")
+	out.WriteString(n.Value.String())
+	return out.String()
+}
+
+func (n *Property) Pos() token.Position {
+	return token.Position{}
+}
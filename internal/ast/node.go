@@ -1,5 +1,9 @@
 package ast
 
+import "github.com/siyul-park/minijs/internal/token"
+
 type Node interface {
 	String() string
+	// Pos returns the position of the token the node is made of, which is invalid for the nodes made only of others.
+	Pos() token.Position
 }
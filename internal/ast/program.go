@@ -1,6 +1,10 @@
 package ast
 
-import "bytes"
+import (
+	"bytes"
+
+	"github.com/siyul-park/minijs/internal/token"
+)
 
 type Program struct {
 	Statements []Statement
@@ -18,3 +22,7 @@ func (p *Program) String() string {
 	}
 	return out.String()
 }
+
+func (p *Program) Pos() token.Position {
+	return token.Position{}
+}
@@ -30,6 +30,10 @@ func (n *EmptyStatement) String() string {
 	return ";"
 }
 
+func (n *EmptyStatement) Pos() token.Position {
+	return token.Position{}
+}
+
 type BlockStatement struct {
 	statement
 	Statements []Statement
@@ -50,6 +54,10 @@ func (n *BlockStatement) String() string {
 	return out.String()
 }
 
+func (n *BlockStatement) Pos() token.Position {
+	return token.Position{}
+}
+
 type ExpressionStatement struct {
 	statement
 	Expression Expression
@@ -63,13 +71,17 @@ func (n *ExpressionStatement) String() string {
 	return n.Expression.String() + ";"
 }
 
+func (n *ExpressionStatement) Pos() token.Position {
+	return token.Position{}
+}
+
 type VariableStatement struct {
 	statement
 	Token token.Token
-	Right []*AssignmentExpression
+	Right []Expression
 }
 
-func NewVariableStatement(token token.Token, right ...*AssignmentExpression) *VariableStatement {
+func NewVariableStatement(token token.Token, right ...Expression) *VariableStatement {
 	return &VariableStatement{Token: token, Right: right}
 }
 
@@ -86,3 +98,380 @@ func (n *VariableStatement) String() string {
 	out.WriteString(";")
 	return out.String()
 }
+
+func (n *VariableStatement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type IfStatement struct {
+	statement
+	Token       token.Token
+	Condition   Expression
+	Consequence Statement
+	Alternative Statement
+}
+
+func NewIfStatement(token token.Token, condition Expression, consequence, alternative Statement) *IfStatement {
+	return &IfStatement{Token: token, Condition: condition, Consequence: consequence, Alternative: alternative}
+}
+
+func (n *IfStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Token.Literal)
+	out.WriteString(" (")
+	out.WriteString(n.Condition.String())
+	out.WriteString(") ")
+	out.WriteString(n.Consequence.String())
+	if n.Alternative != nil {
+		out.WriteString(" else ")
+		out.WriteString(n.Alternative.String())
+	}
+	return out.String()
+}
+
+func (n *IfStatement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type WhileStatement struct {
+	statement
+	Token     token.Token
+	Condition Expression
+	Body      Statement
+}
+
+func NewWhileStatement(token token.Token, condition Expression, body Statement) *WhileStatement {
+	return &WhileStatement{Token: token, Condition: condition, Body: body}
+}
+
+func (n *WhileStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Token.Literal)
+	out.WriteString(" (")
+	out.WriteString(n.Condition.String())
+	out.WriteString(") ")
+	out.WriteString(n.Body.String())
+	return out.String()
+}
+
+func (n *WhileStatement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type DoWhileStatement struct {
+	statement
+	Token     token.Token
+	Body      Statement
+	Condition Expression
+}
+
+func NewDoWhileStatement(token token.Token, body Statement, condition Expression) *DoWhileStatement {
+	return &DoWhileStatement{Token: token, Body: body, Condition: condition}
+}
+
+func (n *DoWhileStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Token.Literal)
+	out.WriteString(" ")
+	out.WriteString(n.Body.String())
+	out.WriteString(" while (")
+	out.WriteString(n.Condition.String())
+	out.WriteString(");")
+	return out.String()
+}
+
+func (n *DoWhileStatement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type ForStatement struct {
+	statement
+	Token     token.Token
+	Init      Statement
+	Condition Expression
+	Update    Expression
+	Body      Statement
+}
+
+func NewForStatement(token token.Token, init Statement, condition, update Expression, body Statement) *ForStatement {
+	return &ForStatement{Token: token, Init: init, Condition: condition, Update: update, Body: body}
+}
+
+func (n *ForStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Token.Literal)
+	out.WriteString(" (")
+	if n.Init != nil {
+		out.WriteString(n.Init.String())
+	} else {
+		out.WriteString(";")
+	}
+	if n.Condition != nil {
+		out.WriteString(" ")
+		out.WriteString(n.Condition.String())
+	}
+	out.WriteString(";")
+	if n.Update != nil {
+		out.WriteString(" ")
+		out.WriteString(n.Update.String())
+	}
+	out.WriteString(") ")
+	out.WriteString(n.Body.String())
+	return out.String()
+}
+
+func (n *ForStatement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type SwitchStatement struct {
+	statement
+	Token        token.Token
+	Discriminant Expression
+	Cases        []*SwitchCase
+}
+
+type SwitchCase struct {
+	Token      token.Token
+	Test       Expression
+	Consequent []Statement
+}
+
+func NewSwitchStatement(token token.Token, discriminant Expression, cases ...*SwitchCase) *SwitchStatement {
+	return &SwitchStatement{Token: token, Discriminant: discriminant, Cases: cases}
+}
+
+func (n *SwitchStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Token.Literal)
+	out.WriteString(" (")
+	out.WriteString(n.Discriminant.String())
+	out.WriteString(") {\n")
+	for _, c := range n.Cases {
+		out.WriteString(c.String())
+		out.WriteString("\n")
+	}
+	out.WriteString("}")
+	return out.String()
+}
+
+func (n *SwitchStatement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+func NewSwitchCase(token token.Token, test Expression, consequent ...Statement) *SwitchCase {
+	return &SwitchCase{Token: token, Test: test, Consequent: consequent}
+}
+
+func (n *SwitchCase) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Token.Literal)
+	if n.Test != nil {
+		out.WriteString(" ")
+		out.WriteString(n.Test.String())
+	}
+	out.WriteString(":")
+	for _, stmt := range n.Consequent {
+		out.WriteString(" ")
+		out.WriteString(stmt.String())
+	}
+	return out.String()
+}
+
+func (n *SwitchCase) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type LabeledStatement struct {
+	statement
+	Label *IdentifierLiteral
+	Body  Statement
+}
+
+func NewLabeledStatement(label *IdentifierLiteral, body Statement) *LabeledStatement {
+	return &LabeledStatement{Label: label, Body: body}
+}
+
+func (n *LabeledStatement) String() string {
+	return n.Label.String() + ": " + n.Body.String()
+}
+
+func (n *LabeledStatement) Pos() token.Position {
+	return token.Position{}
+}
+
+type BreakStatement struct {
+	statement
+	Token token.Token
+	Label *IdentifierLiteral
+}
+
+func NewBreakStatement(token token.Token, label *IdentifierLiteral) *BreakStatement {
+	return &BreakStatement{Token: token, Label: label}
+}
+
+func (n *BreakStatement) String() string {
+	if n.Label != nil {
+		return n.Token.Literal + " " + n.Label.String() + ";"
+	}
+	return n.Token.Literal + ";"
+}
+
+func (n *BreakStatement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type ContinueStatement struct {
+	statement
+	Token token.Token
+	Label *IdentifierLiteral
+}
+
+func NewContinueStatement(token token.Token, label *IdentifierLiteral) *ContinueStatement {
+	return &ContinueStatement{Token: token, Label: label}
+}
+
+func (n *ContinueStatement) String() string {
+	if n.Label != nil {
+		return n.Token.Literal + " " + n.Label.String() + ";"
+	}
+	return n.Token.Literal + ";"
+}
+
+func (n *ContinueStatement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type FunctionDeclaration struct {
+	statement
+	Function *FunctionExpression
+}
+
+func NewFunctionDeclaration(function *FunctionExpression) *FunctionDeclaration {
+	return &FunctionDeclaration{Function: function}
+}
+
+func (n *FunctionDeclaration) String() string {
+	return n.Function.String()
+}
+
+func (n *FunctionDeclaration) Pos() token.Position {
+	return token.Position{}
+}
+
+type ClassDeclaration struct {
+	statement
+	Class *ClassExpression
+}
+
+func NewClassDeclaration(class *ClassExpression) *ClassDeclaration {
+	return &ClassDeclaration{Class: class}
+}
+
+func (n *ClassDeclaration) String() string {
+	return n.Class.String()
+}
+
+func (n *ClassDeclaration) Pos() token.Position {
+	return token.Position{}
+}
+
+type ReturnStatement struct {
+	statement
+	Token    token.Token
+	Argument Expression
+}
+
+func NewReturnStatement(token token.Token, argument Expression) *ReturnStatement {
+	return &ReturnStatement{Token: token, Argument: argument}
+}
+
+func (n *ReturnStatement) String() string {
+	if n.Argument != nil {
+		return n.Token.Literal + " " + n.Argument.String() + ";"
+	}
+	return n.Token.Literal + ";"
+}
+
+func (n *ReturnStatement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+type ThrowStatement struct {
+	statement
+	Token    token.Token
+	Argument Expression
+}
+
+func NewThrowStatement(token token.Token, argument Expression) *ThrowStatement {
+	return &ThrowStatement{Token: token, Argument: argument}
+}
+
+func (n *ThrowStatement) String() string {
+	return n.Token.Literal + " " + n.Argument.String() + ";"
+}
+
+func (n *ThrowStatement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+// TryStatement runs the finalizer however the block and the handler exit, one of which may be missing.
+type TryStatement struct {
+	statement
+	Token     token.Token
+	Block     *BlockStatement
+	Handler   *CatchClause
+	Finalizer *BlockStatement
+}
+
+// CatchClause handles the exception thrown in the block of a try statement, which is bound to the parameter if any.
+type CatchClause struct {
+	Token token.Token
+	Param *IdentifierLiteral
+	Body  *BlockStatement
+}
+
+func NewTryStatement(token token.Token, block *BlockStatement, handler *CatchClause, finalizer *BlockStatement) *TryStatement {
+	return &TryStatement{Token: token, Block: block, Handler: handler, Finalizer: finalizer}
+}
+
+func (n *TryStatement) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Token.Literal)
+	out.WriteString(" ")
+	out.WriteString(n.Block.String())
+	if n.Handler != nil {
+		out.WriteString(" ")
+		out.WriteString(n.Handler.String())
+	}
+	if n.Finalizer != nil {
+		out.WriteString(" finally ")
+		out.WriteString(n.Finalizer.String())
+	}
+	return out.String()
+}
+
+func (n *TryStatement) Pos() token.Position {
+	return n.Token.Pos
+}
+
+func NewCatchClause(token token.Token, param *IdentifierLiteral, body *BlockStatement) *CatchClause {
+	return &CatchClause{Token: token, Param: param, Body: body}
+}
+
+func (n *CatchClause) String() string {
+	var out bytes.Buffer
+	out.WriteString(n.Token.Literal)
+	if n.Param != nil {
+		out.WriteString(" (")
+		out.WriteString(n.Param.String())
+		out.WriteString(")")
+	}
+	out.WriteString(" ")
+	out.WriteString(n.Body.String())
+	return out.String()
+}
+
+func (n *CatchClause) Pos() token.Position {
+	return n.Token.Pos
+}
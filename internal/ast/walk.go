@@ -0,0 +1,155 @@
+package ast
+
+// Inspect traverses the tree in depth-first order, calling f for each node.
+// If f returns false, the children of the node are not visited.
+func Inspect(node Node, f func(Node) bool) {
+	if node == nil || !f(node) {
+		return
+	}
+
+	switch n := node.(type) {
+	case *Program:
+		for _, stmt := range n.Statements {
+			Inspect(stmt, f)
+		}
+	case *BlockStatement:
+		for _, stmt := range n.Statements {
+			Inspect(stmt, f)
+		}
+	case *ExpressionStatement:
+		Inspect(n.Expression, f)
+	case *VariableStatement:
+		for _, exp := range n.Right {
+			Inspect(exp, f)
+		}
+	case *IfStatement:
+		Inspect(n.Condition, f)
+		Inspect(n.Consequence, f)
+		Inspect(n.Alternative, f)
+	case *WhileStatement:
+		Inspect(n.Condition, f)
+		Inspect(n.Body, f)
+	case *DoWhileStatement:
+		Inspect(n.Body, f)
+		Inspect(n.Condition, f)
+	case *ForStatement:
+		Inspect(n.Init, f)
+		Inspect(n.Condition, f)
+		Inspect(n.Update, f)
+		Inspect(n.Body, f)
+	case *SwitchStatement:
+		Inspect(n.Discriminant, f)
+		for _, c := range n.Cases {
+			Inspect(c, f)
+		}
+	case *SwitchCase:
+		Inspect(n.Test, f)
+		for _, stmt := range n.Consequent {
+			Inspect(stmt, f)
+		}
+	case *LabeledStatement:
+		Inspect(n.Label, f)
+		Inspect(n.Body, f)
+	case *FunctionDeclaration:
+		Inspect(n.Function, f)
+	case *ClassDeclaration:
+		Inspect(n.Class, f)
+	case *ReturnStatement:
+		Inspect(n.Argument, f)
+	case *ThrowStatement:
+		Inspect(n.Argument, f)
+	case *TryStatement:
+		Inspect(n.Block, f)
+		if n.Handler != nil {
+			Inspect(n.Handler, f)
+		}
+		if n.Finalizer != nil {
+			Inspect(n.Finalizer, f)
+		}
+	case *CatchClause:
+		if n.Param != nil {
+			Inspect(n.Param, f)
+		}
+		Inspect(n.Body, f)
+	case *PrefixExpression:
+		Inspect(n.Right, f)
+	case *UpdateExpression:
+		Inspect(n.Operand, f)
+	case *SequenceExpression:
+		for _, exp := range n.Expressions {
+			Inspect(exp, f)
+		}
+	case *InfixExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *LogicalExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *ConditionalExpression:
+		Inspect(n.Test, f)
+		Inspect(n.Consequent, f)
+		Inspect(n.Alternative, f)
+	case *AssignmentExpression:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *MemberExpression:
+		Inspect(n.Object, f)
+		Inspect(n.Property, f)
+	case *CallExpression:
+		Inspect(n.Callee, f)
+		for _, arg := range n.Arguments {
+			Inspect(arg, f)
+		}
+	case *NewExpression:
+		Inspect(n.Callee, f)
+		for _, arg := range n.Arguments {
+			Inspect(arg, f)
+		}
+	case *ChainExpression:
+		Inspect(n.Expression, f)
+	case *FunctionExpression:
+		if n.Name != nil {
+			Inspect(n.Name, f)
+		}
+		for _, param := range n.Parameters {
+			Inspect(param, f)
+		}
+		Inspect(n.Body, f)
+	case *SpreadElement:
+		Inspect(n.Argument, f)
+	case *RestElement:
+		Inspect(n.Argument, f)
+	case *ArrayLiteral:
+		for _, elem := range n.Elements {
+			Inspect(elem, f)
+		}
+	case *ObjectLiteral:
+		for _, prop := range n.Properties {
+			Inspect(prop, f)
+		}
+	case *Property:
+		Inspect(n.Key, f)
+		if !n.Shorthand {
+			Inspect(n.Value, f)
+		}
+	case *ClassExpression:
+		if n.Name != nil {
+			Inspect(n.Name, f)
+		}
+		Inspect(n.SuperClass, f)
+		for _, member := range n.Members {
+			Inspect(member, f)
+		}
+	case *MethodDefinition:
+		Inspect(n.Key, f)
+		Inspect(n.Value, f)
+	case *PropertyDefinition:
+		Inspect(n.Key, f)
+		Inspect(n.Value, f)
+	case *ArrowFunctionExpression:
+		for _, param := range n.Parameters {
+			Inspect(param, f)
+		}
+		Inspect(n.Body, f)
+	}
+}
@@ -2,6 +2,7 @@ package bytecode
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -9,6 +10,29 @@ import (
 type Bytecode struct {
 	Instructions []byte
 	Constants    []byte
+	Handlers     []Handler
+	Positions    []Position
+	Globals      []Global
+}
+
+// Handler catches the exceptions thrown by the instructions from the start up to the end, continuing at the target.
+type Handler struct {
+	Start  int
+	End    int
+	Target int
+}
+
+// Position locates the instructions from the offset in the source.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// Global binds the property of the global object named after the name to the slot of the global frame.
+type Global struct {
+	Name  string
+	Index int
 }
 
 func (b *Bytecode) Emit(instructions ...Instruction) int {
@@ -31,6 +55,14 @@ func (b *Bytecode) Fetch(offset int) (Instruction, int) {
 	return b.Instructions[offset : offset+width], width
 }
 
+func (b *Bytecode) Locate(offset int) (Position, bool) {
+	i := sort.Search(len(b.Positions), func(i int) bool { return b.Positions[i].Offset > offset })
+	if i == 0 {
+		return Position{}, false
+	}
+	return b.Positions[i-1], true
+}
+
 func (b *Bytecode) Store(constants []byte) int {
 	offset := len(b.Constants)
 	b.Constants = append(b.Constants, constants...)
@@ -64,5 +96,26 @@ func (b *Bytecode) String() string {
 		out.WriteString("\n")
 	}
 
+	if len(b.Handlers) > 0 {
+		out.WriteString("\n.section .handlers:\n")
+		for _, h := range b.Handlers {
+			fmt.Fprintf(&out, " \t0x%08X 0x%08X 0x%08X\n", h.Start, h.End, h.Target)
+		}
+	}
+
+	if len(b.Positions) > 0 {
+		out.WriteString("\n.section .positions:\n")
+		for _, p := range b.Positions {
+			fmt.Fprintf(&out, " \t0x%08X %d:%d\n", p.Offset, p.Line, p.Column)
+		}
+	}
+
+	if len(b.Globals) > 0 {
+		out.WriteString("\n.section .globals:\n")
+		for _, g := range b.Globals {
+			fmt.Fprintf(&out, " \t0x%04X %s\n", g.Index, g.Name)
+		}
+	}
+
 	return out.String()
 }
@@ -18,21 +18,59 @@ type Type struct {
 const (
 	NOP Opcode = iota
 	POP
+	DUP
+	DUP2
+	INSERT
+	STRICT
+
+	JMP
+	JMPT
+	JMPF
+
+	CALL
+	APPLY
+	NEW
+	NEWAPPLY
+	SUPERCALL
+	SUPERAPPLY
+	RETURN
+	THROW
 
 	SLTLOAD
 	SLTSTORE
+	SLTCLEAR
+	SLTCHECK
+	SLTBOX
+
+	CELLLOAD
+	CELLSTORE
+	CELLCHECK
+
+	UPVLOAD
+	UPVSTORE
+	UPVCHECK
+
+	GLBLOAD
+	GLBSTORE
+	GLBCHECK
+	GLBHOLE
+
+	BUILTINLOAD
 
 	UNDEFLOAD
+	UNDEFTOBOOL
 	UNDEFTOF64
 	UNDEFTOSTR
 
 	NULLLOAD
+	NULLTOBOOL
 	NULLTOI32
 	NULLTOSTR
 
 	BOOLLOAD
 	BOOLTOI32
 	BOOLTOSTR
+	BOOLNOT
 
 	I32LOAD
 	I32MUL
@@ -43,6 +81,20 @@ const (
 	I32TOBOOL
 	I32TOF64
 	I32TOSTR
+	I32EQ
+	I32NE
+	I32LT
+	I32GT
+	I32LE
+	I32GE
+	I32AND
+	I32OR
+	I32XOR
+	I32NOT
+	I32SHL
+	I32SHR
+	I32USHR
+	I32SWITCH
 
 	F64LOAD
 	F64ADD
@@ -50,33 +102,131 @@ const (
 	F64MUL
 	F64DIV
 	F64MOD
+	F64POW
+	F64TOBOOL
 	F64TOI32
 	F64TOSTR
+	F64EQ
+	F64NE
+	F64LT
+	F64GT
+	F64LE
+	F64GE
 
 	STRLOAD
 	STRADD
+	STRTOBOOL
 	STRTOI32
 	STRTOF64
+	STREQ
+	STRNE
+	STRLT
+	STRGT
+	STRLE
+	STRGE
+
+	FNLOAD
+	FNCAPTURE
+	FNHOME
+	FNEXTEND
+	FNFIELDS
+	THISLOAD
+	THISINIT
+	THISFIELDS
+	ARGSLOAD
+	RESTLOAD
+	PRIVNEW
+
+	ARRNEW
+	ARRPUSH
+	ARRSPREAD
+	ARRHOLE
+
+	OBJNEW
+	OBJPUT
+	OBJMETHOD
+	OBJGETTER
+	OBJSETTER
+
+	ANYADD
+	ANYTOBOOL
+	ANYTOI32
+	ANYTOF64
+	ANYTOSTR
+	ANYTYPEOF
+	ANYNULLISH
+	ANYGET
+	ANYSET
+	SUPERGET
+	SUPERSET
+	ANYDELETE
+	ANYEQ
+	ANYNE
+	ANYSTRICTEQ
+	ANYSTRICTNE
+	ANYLT
+	ANYGT
+	ANYLE
+	ANYGE
+	ANYINSTANCEOF
 )
 
 var types = map[Opcode]*Type{
-	NOP: {Mnemonic: "nop"},
-	POP: {Mnemonic: "pop"},
+	NOP:    {Mnemonic: "nop"},
+	POP:    {Mnemonic: "pop"},
+	DUP:    {Mnemonic: "dup"},
+	DUP2:   {Mnemonic: "dup2"},
+	INSERT: {Mnemonic: "insert", Widths: []int{1}},
+	STRICT: {Mnemonic: "strict"},
+
+	JMP:  {Mnemonic: "jmp", Widths: []int{4}},
+	JMPT: {Mnemonic: "jmp.true", Widths: []int{4}},
+	JMPF: {Mnemonic: "jmp.false", Widths: []int{4}},
+
+	CALL:       {Mnemonic: "call", Widths: []int{1}},
+	APPLY:      {Mnemonic: "apply"},
+	NEW:        {Mnemonic: "new", Widths: []int{1}},
+	NEWAPPLY:   {Mnemonic: "new.apply"},
+	SUPERCALL:  {Mnemonic: "super.call", Widths: []int{1}},
+	SUPERAPPLY: {Mnemonic: "super.apply"},
+	RETURN:     {Mnemonic: "return"},
+	THROW:      {Mnemonic: "throw"},
 
 	SLTLOAD:  {Mnemonic: "slot.load", Widths: []int{2}},
 	SLTSTORE: {Mnemonic: "slot.store", Widths: []int{2}},
+	SLTCLEAR: {Mnemonic: "slot.clear", Widths: []int{2}},
+	SLTCHECK: {Mnemonic: "slot.check", Widths: []int{2, 4, 4}},
+	SLTBOX:   {Mnemonic: "slot.box", Widths: []int{2}},
+
+	CELLLOAD:  {Mnemonic: "cell.load", Widths: []int{2}},
+	CELLSTORE: {Mnemonic: "cell.store", Widths: []int{2}},
+	CELLCHECK: {Mnemonic: "cell.check", Widths: []int{2, 4, 4}},
 
-	UNDEFLOAD:  {Mnemonic: "undef.load"},
-	UNDEFTOF64: {Mnemonic: "undef.to_f64"},
-	UNDEFTOSTR: {Mnemonic: "undef.to_str"},
+	UPVLOAD:  {Mnemonic: "upvalue.load", Widths: []int{2}},
+	UPVSTORE: {Mnemonic: "upvalue.store", Widths: []int{2}},
+	UPVCHECK: {Mnemonic: "upvalue.check", Widths: []int{2, 4, 4}},
 
-	NULLLOAD:  {Mnemonic: "null.load"},
-	NULLTOI32: {Mnemonic: "null.to_i32"},
-	NULLTOSTR: {Mnemonic: "null.to_str"},
+	GLBLOAD:  {Mnemonic: "global.load", Widths: []int{2}},
+	GLBSTORE: {Mnemonic: "global.store", Widths: []int{2}},
+	GLBCHECK: {Mnemonic: "global.check", Widths: []int{2, 4, 4}},
+	GLBHOLE:  {Mnemonic: "global.hole", Widths: []int{2, 4, 4}},
+
+	BUILTINLOAD: {Mnemonic: "builtin.load", Widths: []int{4, 4}},
+
+	UNDEFLOAD:   {Mnemonic: "undef.load"},
+	UNDEFTOBOOL: {Mnemonic: "undef.to_bool"},
+	UNDEFTOF64:  {Mnemonic: "undef.to_f64"},
+	UNDEFTOSTR:  {Mnemonic: "undef.to_str"},
+
+	NULLLOAD:   {Mnemonic: "null.load"},
+	NULLTOBOOL: {Mnemonic: "null.to_bool"},
+	NULLTOI32:  {Mnemonic: "null.to_i32"},
+	NULLTOSTR:  {Mnemonic: "null.to_str"},
 
 	BOOLLOAD:  {Mnemonic: "bool.load", Widths: []int{1}},
 	BOOLTOI32: {Mnemonic: "bool.to_i32"},
 	BOOLTOSTR: {Mnemonic: "bool.to_str"},
+	BOOLNOT:   {Mnemonic: "bool.not"},
 
 	I32LOAD:   {Mnemonic: "i32.load", Widths: []int{4}},
 	I32MUL:    {Mnemonic: "i32.mul"},
@@ -87,20 +237,95 @@ var types = map[Opcode]*Type{
 	I32TOBOOL: {Mnemonic: "i32.to_bool"},
 	I32TOF64:  {Mnemonic: "i32.to_f64"},
 	I32TOSTR:  {Mnemonic: "i32.to_str"},
-
-	F64LOAD:  {Mnemonic: "f64.load", Widths: []int{8}},
-	F64ADD:   {Mnemonic: "f64.add"},
-	F64SUB:   {Mnemonic: "f64.sub"},
-	F64MUL:   {Mnemonic: "f64.mul"},
-	F64DIV:   {Mnemonic: "f64.div"},
-	F64MOD:   {Mnemonic: "f64.mod"},
-	F64TOI32: {Mnemonic: "f64.to_i32"},
-	F64TOSTR: {Mnemonic: "f64.to_str"},
-
-	STRLOAD:  {Mnemonic: "str.load", Widths: []int{4, 4}},
-	STRADD:   {Mnemonic: "str.add"},
-	STRTOI32: {Mnemonic: "str.to_i32"},
-	STRTOF64: {Mnemonic: "str.to_f64"},
+	I32EQ:     {Mnemonic: "i32.eq"},
+	I32NE:     {Mnemonic: "i32.ne"},
+	I32LT:     {Mnemonic: "i32.lt"},
+	I32GT:     {Mnemonic: "i32.gt"},
+	I32LE:     {Mnemonic: "i32.le"},
+	I32GE:     {Mnemonic: "i32.ge"},
+	I32AND:    {Mnemonic: "i32.and"},
+	I32OR:     {Mnemonic: "i32.or"},
+	I32XOR:    {Mnemonic: "i32.xor"},
+	I32NOT:    {Mnemonic: "i32.not"},
+	I32SHL:    {Mnemonic: "i32.shl"},
+	I32SHR:    {Mnemonic: "i32.shr"},
+	I32USHR:   {Mnemonic: "i32.ushr"},
+	I32SWITCH: {Mnemonic: "i32.switch", Widths: []int{4, 4}},
+
+	F64LOAD:   {Mnemonic: "f64.load", Widths: []int{8}},
+	F64ADD:    {Mnemonic: "f64.add"},
+	F64SUB:    {Mnemonic: "f64.sub"},
+	F64MUL:    {Mnemonic: "f64.mul"},
+	F64DIV:    {Mnemonic: "f64.div"},
+	F64MOD:    {Mnemonic: "f64.mod"},
+	F64POW:    {Mnemonic: "f64.pow"},
+	F64TOBOOL: {Mnemonic: "f64.to_bool"},
+	F64TOI32:  {Mnemonic: "f64.to_i32"},
+	F64TOSTR:  {Mnemonic: "f64.to_str"},
+	F64EQ:     {Mnemonic: "f64.eq"},
+	F64NE:     {Mnemonic: "f64.ne"},
+	F64LT:     {Mnemonic: "f64.lt"},
+	F64GT:     {Mnemonic: "f64.gt"},
+	F64LE:     {Mnemonic: "f64.le"},
+	F64GE:     {Mnemonic: "f64.ge"},
+
+	STRLOAD:   {Mnemonic: "str.load", Widths: []int{4, 4}},
+	STRADD:    {Mnemonic: "str.add"},
+	STRTOBOOL: {Mnemonic: "str.to_bool"},
+	STRTOI32:  {Mnemonic: "str.to_i32"},
+	STRTOF64:  {Mnemonic: "str.to_f64"},
+	STREQ:     {Mnemonic: "str.eq"},
+	STRNE:     {Mnemonic: "str.ne"},
+	STRLT:     {Mnemonic: "str.lt"},
+	STRGT:     {Mnemonic: "str.gt"},
+	STRLE:     {Mnemonic: "str.le"},
+	STRGE:     {Mnemonic: "str.ge"},
+
+	FNLOAD:    {Mnemonic: "fn.load", Widths: []int{4, 1, 1, 4, 4, 1}},
+	FNCAPTURE: {Mnemonic: "fn.capture", Widths: []int{1, 2}},
+	FNHOME:    {Mnemonic: "fn.home"},
+	FNEXTEND:  {Mnemonic: "fn.extend"},
+	FNFIELDS:  {Mnemonic: "fn.fields"},
+
+	THISLOAD:   {Mnemonic: "this.load"},
+	THISINIT:   {Mnemonic: "this.init"},
+	THISFIELDS: {Mnemonic: "this.fields"},
+	ARGSLOAD:   {Mnemonic: "args.load"},
+	RESTLOAD:   {Mnemonic: "rest.load"},
+	PRIVNEW:    {Mnemonic: "priv.new"},
+
+	ARRNEW:    {Mnemonic: "arr.new"},
+	ARRPUSH:   {Mnemonic: "arr.push"},
+	ARRSPREAD: {Mnemonic: "arr.spread"},
+	ARRHOLE:   {Mnemonic: "arr.hole"},
+
+	OBJNEW:    {Mnemonic: "obj.new"},
+	OBJPUT:    {Mnemonic: "obj.put"},
+	OBJMETHOD: {Mnemonic: "obj.method", Widths: []int{1}},
+	OBJGETTER: {Mnemonic: "obj.getter", Widths: []int{1}},
+	OBJSETTER: {Mnemonic: "obj.setter", Widths: []int{1}},
+
+	ANYADD:        {Mnemonic: "any.add"},
+	ANYTOBOOL:     {Mnemonic: "any.to_bool"},
+	ANYTOI32:      {Mnemonic: "any.to_i32"},
+	ANYTOF64:      {Mnemonic: "any.to_f64"},
+	ANYTOSTR:      {Mnemonic: "any.to_str"},
+	ANYTYPEOF:     {Mnemonic: "any.typeof"},
+	ANYNULLISH:    {Mnemonic: "any.nullish"},
+	ANYGET:        {Mnemonic: "any.get"},
+	ANYSET:        {Mnemonic: "any.set"},
+	SUPERGET:      {Mnemonic: "super.get"},
+	SUPERSET:      {Mnemonic: "super.set"},
+	ANYDELETE:     {Mnemonic: "any.delete"},
+	ANYEQ:         {Mnemonic: "any.eq"},
+	ANYNE:         {Mnemonic: "any.ne"},
+	ANYSTRICTEQ:   {Mnemonic: "any.strict_eq"},
+	ANYSTRICTNE:   {Mnemonic: "any.strict_ne"},
+	ANYLT:         {Mnemonic: "any.lt"},
+	ANYGT:         {Mnemonic: "any.gt"},
+	ANYLE:         {Mnemonic: "any.le"},
+	ANYGE:         {Mnemonic: "any.ge"},
+	ANYINSTANCEOF: {Mnemonic: "any.instanceof"},
 }
 
 func TypeOf(op Opcode) *Type {
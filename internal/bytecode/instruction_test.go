@@ -13,21 +13,57 @@ func TestInstruction_String(t *testing.T) {
 	}{
 		{instruction: New(NOP), expect: "nop"},
 		{instruction: New(POP), expect: "pop"},
+		{instruction: New(DUP), expect: "dup"},
+		{instruction: New(DUP2), expect: "dup2"},
+		{instruction: New(INSERT, 2), expect: "insert 0x02"},
+		{instruction: New(STRICT), expect: "strict"},
+
+		{instruction: New(JMP, 0x01), expect: "jmp 0x00000001"},
+		{instruction: New(JMPT, 0x01), expect: "jmp.true 0x00000001"},
+		{instruction: New(JMPF, 0x01), expect: "jmp.false 0x00000001"},
+		{instruction: New(CALL, 0x01), expect: "call 0x01"},
+		{instruction: New(APPLY), expect: "apply"},
+		{instruction: New(NEW, 0x01), expect: "new 0x01"},
+		{instruction: New(NEWAPPLY), expect: "new.apply"},
+		{instruction: New(SUPERCALL, 0x01), expect: "super.call 0x01"},
+		{instruction: New(SUPERAPPLY), expect: "super.apply"},
+		{instruction: New(RETURN), expect: "return"},
+		{instruction: New(THROW), expect: "throw"},
 
 		{instruction: New(SLTLOAD, 0x01), expect: "slot.load 0x0001"},
 		{instruction: New(SLTSTORE, 0x01), expect: "slot.store 0x0001"},
+		{instruction: New(SLTCLEAR, 0x01), expect: "slot.clear 0x0001"},
+		{instruction: New(SLTCHECK, 0x01, 0x00, 0x01), expect: "slot.check 0x0001 0x00000000 0x00000001"},
+		{instruction: New(SLTBOX, 0x01), expect: "slot.box 0x0001"},
+
+		{instruction: New(CELLLOAD, 0x01), expect: "cell.load 0x0001"},
+		{instruction: New(CELLSTORE, 0x01), expect: "cell.store 0x0001"},
+		{instruction: New(CELLCHECK, 0x01, 0x00, 0x01), expect: "cell.check 0x0001 0x00000000 0x00000001"},
+
+		{instruction: New(UPVLOAD, 0x01), expect: "upvalue.load 0x0001"},
+		{instruction: New(UPVSTORE, 0x01), expect: "upvalue.store 0x0001"},
+		{instruction: New(UPVCHECK, 0x01, 0x00, 0x01), expect: "upvalue.check 0x0001 0x00000000 0x00000001"},
+
+		{instruction: New(GLBLOAD, 0x01), expect: "global.load 0x0001"},
+		{instruction: New(GLBSTORE, 0x01), expect: "global.store 0x0001"},
+		{instruction: New(GLBCHECK, 0x01, 0x00, 0x01), expect: "global.check 0x0001 0x00000000 0x00000001"},
+		{instruction: New(GLBHOLE, 0x01, 0x00, 0x01), expect: "global.hole 0x0001 0x00000000 0x00000001"},
+		{instruction: New(BUILTINLOAD, 0x00, 0x06), expect: "builtin.load 0x00000000 0x00000006"},
 
 		{instruction: New(UNDEFLOAD), expect: "undef.load"},
+		{instruction: New(UNDEFTOBOOL), expect: "undef.to_bool"},
 		{instruction: New(UNDEFTOF64), expect: "undef.to_f64"},
 		{instruction: New(UNDEFTOSTR), expect: "undef.to_str"},
 
 		{instruction: New(NULLLOAD), expect: "null.load"},
+		{instruction: New(NULLTOBOOL), expect: "null.to_bool"},
 		{instruction: New(NULLTOI32), expect: "null.to_i32"},
 		{instruction: New(NULLTOSTR), expect: "null.to_str"},
 
 		{instruction: New(BOOLLOAD, 0x01), expect: "bool.load 0x01"},
 		{instruction: New(BOOLTOI32), expect: "bool.to_i32"},
 		{instruction: New(BOOLTOSTR), expect: "bool.to_str"},
+		{instruction: New(BOOLNOT), expect: "bool.not"},
 
 		{instruction: New(I32LOAD, 0x01), expect: "i32.load 0x00000001"},
 		{instruction: New(I32MUL), expect: "i32.mul"},
@@ -38,6 +74,20 @@ func TestInstruction_String(t *testing.T) {
 		{instruction: New(I32TOBOOL), expect: "i32.to_bool"},
 		{instruction: New(I32TOF64), expect: "i32.to_f64"},
 		{instruction: New(I32TOSTR), expect: "i32.to_str"},
+		{instruction: New(I32EQ), expect: "i32.eq"},
+		{instruction: New(I32NE), expect: "i32.ne"},
+		{instruction: New(I32LT), expect: "i32.lt"},
+		{instruction: New(I32GT), expect: "i32.gt"},
+		{instruction: New(I32LE), expect: "i32.le"},
+		{instruction: New(I32GE), expect: "i32.ge"},
+		{instruction: New(I32AND), expect: "i32.and"},
+		{instruction: New(I32OR), expect: "i32.or"},
+		{instruction: New(I32XOR), expect: "i32.xor"},
+		{instruction: New(I32NOT), expect: "i32.not"},
+		{instruction: New(I32SHL), expect: "i32.shl"},
+		{instruction: New(I32SHR), expect: "i32.shr"},
+		{instruction: New(I32USHR), expect: "i32.ushr"},
+		{instruction: New(I32SWITCH, 0x01, 0x02), expect: "i32.switch 0x00000001 0x00000002"},
 
 		{instruction: New(F64LOAD, 0x01), expect: "f64.load 0x0000000000000001"},
 		{instruction: New(F64ADD), expect: "f64.add"},
@@ -45,13 +95,72 @@ func TestInstruction_String(t *testing.T) {
 		{instruction: New(F64MUL), expect: "f64.mul"},
 		{instruction: New(F64DIV), expect: "f64.div"},
 		{instruction: New(F64MOD), expect: "f64.mod"},
+		{instruction: New(F64POW), expect: "f64.pow"},
+		{instruction: New(F64TOBOOL), expect: "f64.to_bool"},
 		{instruction: New(F64TOI32), expect: "f64.to_i32"},
 		{instruction: New(F64TOSTR), expect: "f64.to_str"},
+		{instruction: New(F64EQ), expect: "f64.eq"},
+		{instruction: New(F64NE), expect: "f64.ne"},
+		{instruction: New(F64LT), expect: "f64.lt"},
+		{instruction: New(F64GT), expect: "f64.gt"},
+		{instruction: New(F64LE), expect: "f64.le"},
+		{instruction: New(F64GE), expect: "f64.ge"},
 
 		{instruction: New(STRLOAD, 0x01, 0x01), expect: "str.load 0x00000001 0x00000001"},
 		{instruction: New(STRADD), expect: "str.add"},
+		{instruction: New(STRTOBOOL), expect: "str.to_bool"},
 		{instruction: New(STRTOI32), expect: "str.to_i32"},
 		{instruction: New(STRTOF64), expect: "str.to_f64"},
+		{instruction: New(STREQ), expect: "str.eq"},
+		{instruction: New(STRNE), expect: "str.ne"},
+		{instruction: New(STRLT), expect: "str.lt"},
+		{instruction: New(STRGT), expect: "str.gt"},
+		{instruction: New(STRLE), expect: "str.le"},
+		{instruction: New(STRGE), expect: "str.ge"},
+
+		{instruction: New(FNLOAD, 0x05, 0x02, 0x01, 0x00, 0x01, 0x01), expect: "fn.load 0x00000005 0x02 0x01 0x00000000 0x00000001 0x01"},
+		{instruction: New(FNCAPTURE, 0x00, 0x01), expect: "fn.capture 0x00 0x0001"},
+		{instruction: New(FNHOME), expect: "fn.home"},
+		{instruction: New(FNEXTEND), expect: "fn.extend"},
+		{instruction: New(FNFIELDS), expect: "fn.fields"},
+		{instruction: New(THISLOAD), expect: "this.load"},
+		{instruction: New(THISINIT), expect: "this.init"},
+		{instruction: New(THISFIELDS), expect: "this.fields"},
+		{instruction: New(ARGSLOAD), expect: "args.load"},
+		{instruction: New(RESTLOAD), expect: "rest.load"},
+		{instruction: New(PRIVNEW), expect: "priv.new"},
+
+		{instruction: New(ARRNEW), expect: "arr.new"},
+		{instruction: New(ARRPUSH), expect: "arr.push"},
+		{instruction: New(ARRSPREAD), expect: "arr.spread"},
+		{instruction: New(ARRHOLE), expect: "arr.hole"},
+		{instruction: New(OBJNEW), expect: "obj.new"},
+		{instruction: New(OBJPUT), expect: "obj.put"},
+		{instruction: New(OBJMETHOD, 3), expect: "obj.method 0x03"},
+		{instruction: New(OBJGETTER, 4), expect: "obj.getter 0x04"},
+		{instruction: New(OBJSETTER, 6), expect: "obj.setter 0x06"},
+
+		{instruction: New(ANYADD), expect: "any.add"},
+		{instruction: New(ANYTOBOOL), expect: "any.to_bool"},
+		{instruction: New(ANYTOI32), expect: "any.to_i32"},
+		{instruction: New(ANYTOF64), expect: "any.to_f64"},
+		{instruction: New(ANYTOSTR), expect: "any.to_str"},
+		{instruction: New(ANYTYPEOF), expect: "any.typeof"},
+		{instruction: New(ANYNULLISH), expect: "any.nullish"},
+		{instruction: New(ANYGET), expect: "any.get"},
+		{instruction: New(ANYSET), expect: "any.set"},
+		{instruction: New(SUPERGET), expect: "super.get"},
+		{instruction: New(SUPERSET), expect: "super.set"},
+		{instruction: New(ANYDELETE), expect: "any.delete"},
+		{instruction: New(ANYEQ), expect: "any.eq"},
+		{instruction: New(ANYNE), expect: "any.ne"},
+		{instruction: New(ANYSTRICTEQ), expect: "any.strict_eq"},
+		{instruction: New(ANYSTRICTNE), expect: "any.strict_ne"},
+		{instruction: New(ANYLT), expect: "any.lt"},
+		{instruction: New(ANYGT), expect: "any.gt"},
+		{instruction: New(ANYLE), expect: "any.le"},
+		{instruction: New(ANYGE), expect: "any.ge"},
+		{instruction: New(ANYINSTANCEOF), expect: "any.instanceof"},
 	}
 
 	for _, test := range tests {
@@ -3,7 +3,9 @@ package compiler
 import (
 	"bytes"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
 	"strings"
 
 	"github.com/siyul-park/minijs/internal/ast"
@@ -16,21 +18,128 @@ type Compiler struct {
 	instructions []bytecode.Instruction
 	constants    [][]byte
 	symbolTable  *SymbolTable
+	frames       []*frame
+	loops        []*loop
+	labels       []string
+	chains       [][]branch
+	guards       []*guard
+	handlers     []handler
+	positions    []position
+	pos          token.Position
+}
+
+// frame holds the upvalues of the function being compiled.
+type frame struct {
+	symbolTable *SymbolTable
+	upvalues    []capture
+	method      *method
+	strict      bool
+}
+
+// method tells how a method of a class may refer to super.
+type method struct {
+	derived bool
+	fields  bool
+}
+
+// capture binds an upvalue to a cell or an upvalue of the enclosing frame.
+type capture struct {
+	symbol *Symbol
+	local  bool
+	index  int
+}
+
+// storage tells where a symbol lives from the view of the current frame.
+type storage int
+
+const (
+	slot storage = iota
+	cell
+	global
+	upvalue
+)
+
+type loop struct {
+	labels    []string
+	breakable bool
+	iteration bool
+	breaks    []branch
+	continues []branch
+	exits     []map[*Symbol]interpreter.Type
+}
+
+type branch struct {
+	index int
+	types map[*Symbol]interpreter.Type
+}
+
+// guard tracks a try block or catch clause whose exceptions the code after it handles.
+type guard struct {
+	finalizer *ast.BlockStatement
+	loops     int
+	start     int
+	gaps      [][2]int
+	writes    map[*Symbol]bool
+}
+
+type position struct {
+	index int
+	pos   token.Position
+}
+
+// handler directs the exceptions thrown from start up to end to the target.
+type handler struct {
+	start  int
+	end    int
+	target int
+}
+
+// snapshot holds the state of the compiler to restore when compiling a program fails.
+type snapshot struct {
+	instructions int
+	constants    int
+	handlers     int
+	positions    int
+	symbolTable  *SymbolTable
+	symbols      map[string]*Symbol
+	values       map[*Symbol]Symbol
+	captures     map[string]bool
+	size         int
+	peak         int
+	frames       int
+	frame        frame
+	loops        int
+	labels       int
+	chains       int
+	guards       int
+}
+
+// stacked stands for a value already on the stack.
+type stacked struct {
+	ast.Expression
+}
+
+func (*stacked) String() string {
+	return ""
+}
+
+func (*stacked) Pos() token.Position {
+	return token.Position{}
 }
 
 var casts = map[interpreter.Type]map[interpreter.Type][]bytecode.Instruction{
 	interpreter.UNDEFINED: {
 		interpreter.UNDEFINED: {},
 		interpreter.NULL:      {},
-		interpreter.BOOL:      {},
-		interpreter.INT32:     {},
+		interpreter.BOOL:      {bytecode.New(bytecode.UNDEFTOBOOL)},
+		interpreter.INT32:     {bytecode.New(bytecode.UNDEFTOF64), bytecode.New(bytecode.F64TOI32)},
 		interpreter.FLOAT64:   {bytecode.New(bytecode.UNDEFTOF64)},
 		interpreter.STRING:    {bytecode.New(bytecode.UNDEFTOSTR)},
 	},
 	interpreter.NULL: {
 		interpreter.UNDEFINED: {},
 		interpreter.NULL:      {},
-		interpreter.BOOL:      {},
+		interpreter.BOOL:      {bytecode.New(bytecode.NULLTOBOOL)},
 		interpreter.INT32:     {bytecode.New(bytecode.NULLTOI32)},
 		interpreter.FLOAT64:   {bytecode.New(bytecode.NULLTOI32), bytecode.New(bytecode.I32TOF64)},
 		interpreter.STRING:    {bytecode.New(bytecode.NULLTOSTR)},
@@ -54,7 +163,7 @@ var casts = map[interpreter.Type]map[interpreter.Type][]bytecode.Instruction{
 	interpreter.FLOAT64: {
 		interpreter.UNDEFINED: {},
 		interpreter.NULL:      {},
-		interpreter.BOOL:      {},
+		interpreter.BOOL:      {bytecode.New(bytecode.F64TOBOOL)},
 		interpreter.INT32:     {bytecode.New(bytecode.F64TOI32)},
 		interpreter.FLOAT64:   {},
 		interpreter.STRING:    {bytecode.New(bytecode.F64TOSTR)},
@@ -62,27 +171,177 @@ var casts = map[interpreter.Type]map[interpreter.Type][]bytecode.Instruction{
 	interpreter.STRING: {
 		interpreter.UNDEFINED: {},
 		interpreter.NULL:      {},
-		interpreter.BOOL:      {},
+		interpreter.BOOL:      {bytecode.New(bytecode.STRTOBOOL)},
 		interpreter.INT32:     {bytecode.New(bytecode.STRTOI32)},
 		interpreter.FLOAT64:   {bytecode.New(bytecode.STRTOF64)},
 		interpreter.STRING:    {},
 	},
+	interpreter.UNKNOWN: {
+		interpreter.BOOL:    {bytecode.New(bytecode.ANYTOBOOL)},
+		interpreter.INT32:   {bytecode.New(bytecode.ANYTOI32)},
+		interpreter.FLOAT64: {bytecode.New(bytecode.ANYTOF64)},
+		interpreter.STRING:  {bytecode.New(bytecode.ANYTOSTR)},
+	},
+}
+
+var comparisons = map[interpreter.Type]map[token.Type]bytecode.Opcode{
+	interpreter.INT32: {
+		token.EQUAL:                 bytecode.I32EQ,
+		token.NOT_EQUAL:             bytecode.I32NE,
+		token.IDENTITY_EQUAL:        bytecode.I32EQ,
+		token.IDENTITY_NOT_EQUAL:    bytecode.I32NE,
+		token.LESS_THAN:             bytecode.I32LT,
+		token.GREATER_THAN:          bytecode.I32GT,
+		token.LESS_THAN_OR_EQUAL:    bytecode.I32LE,
+		token.GREATER_THAN_OR_EQUAL: bytecode.I32GE,
+	},
+	interpreter.FLOAT64: {
+		token.EQUAL:                 bytecode.F64EQ,
+		token.NOT_EQUAL:             bytecode.F64NE,
+		token.IDENTITY_EQUAL:        bytecode.F64EQ,
+		token.IDENTITY_NOT_EQUAL:    bytecode.F64NE,
+		token.LESS_THAN:             bytecode.F64LT,
+		token.GREATER_THAN:          bytecode.F64GT,
+		token.LESS_THAN_OR_EQUAL:    bytecode.F64LE,
+		token.GREATER_THAN_OR_EQUAL: bytecode.F64GE,
+	},
+	interpreter.STRING: {
+		token.EQUAL:                 bytecode.STREQ,
+		token.NOT_EQUAL:             bytecode.STRNE,
+		token.IDENTITY_EQUAL:        bytecode.STREQ,
+		token.IDENTITY_NOT_EQUAL:    bytecode.STRNE,
+		token.LESS_THAN:             bytecode.STRLT,
+		token.GREATER_THAN:          bytecode.STRGT,
+		token.LESS_THAN_OR_EQUAL:    bytecode.STRLE,
+		token.GREATER_THAN_OR_EQUAL: bytecode.STRGE,
+	},
+	interpreter.UNKNOWN: {
+		token.EQUAL:                 bytecode.ANYEQ,
+		token.NOT_EQUAL:             bytecode.ANYNE,
+		token.IDENTITY_EQUAL:        bytecode.ANYSTRICTEQ,
+		token.IDENTITY_NOT_EQUAL:    bytecode.ANYSTRICTNE,
+		token.LESS_THAN:             bytecode.ANYLT,
+		token.GREATER_THAN:          bytecode.ANYGT,
+		token.LESS_THAN_OR_EQUAL:    bytecode.ANYLE,
+		token.GREATER_THAN_OR_EQUAL: bytecode.ANYGE,
+	},
+}
+
+var logicals = map[token.Type]token.Type{
+	token.AND_ASSIGN:     token.AND,
+	token.OR_ASSIGN:      token.OR,
+	token.NULLISH_ASSIGN: token.NULLISH,
+}
+
+var bitwises = map[token.Type]bytecode.Opcode{
+	token.BIT_AND:                bytecode.I32AND,
+	token.BIT_OR:                 bytecode.I32OR,
+	token.BIT_XOR:                bytecode.I32XOR,
+	token.LEFT_SHIFT_ARITHMETIC:  bytecode.I32SHL,
+	token.RIGHT_SHIFT_ARITHMETIC: bytecode.I32SHR,
+	token.RIGHT_SHIFT_LOGICAL:    bytecode.I32USHR,
+}
+
+var compounds = map[token.Type]token.Type{
+	token.PLUS_ASSIGN:                   token.PLUS,
+	token.MINUS_ASSIGN:                  token.MINUS,
+	token.MULTIPLY_ASSIGN:               token.MULTIPLY,
+	token.DIVIDE_ASSIGN:                 token.DIVIDE,
+	token.MODULUS_ASSIGN:                token.MODULUS,
+	token.EXPONENT_ASSIGN:               token.EXPONENT,
+	token.LEFT_SHIFT_ARITHMETIC_ASSIGN:  token.LEFT_SHIFT_ARITHMETIC,
+	token.RIGHT_SHIFT_ARITHMETIC_ASSIGN: token.RIGHT_SHIFT_ARITHMETIC,
+	token.RIGHT_SHIFT_LOGICAL_ASSIGN:    token.RIGHT_SHIFT_LOGICAL,
+	token.BIT_AND_ASSIGN:                token.BIT_AND,
+	token.BIT_OR_ASSIGN:                 token.BIT_OR,
+	token.BIT_XOR_ASSIGN:                token.BIT_XOR,
 }
 
 func New() *Compiler {
+	symbolTable := NewSymbolTable()
 	return &Compiler{
-		symbolTable: NewSymbolTable(),
+		symbolTable: symbolTable,
+		frames:      []*frame{{symbolTable: symbolTable}},
 	}
 }
 
 func (c *Compiler) Compile(node ast.Node) (bytecode.Bytecode, error) {
-	if err := c.compile(node); err != nil {
+	s := c.snapshot()
+	for _, sym := range c.symbolTable.Root().symbols {
+		// A failed program may have left its lexical declarations in the temporal dead zone.
+		if sym.Kind == LET || sym.Kind == CONST {
+			sym.Initialized = false
+		}
+	}
+	c.symbolTable.Capture(c.captures(node)...)
+	err := c.compile(node)
+	if err == nil && c.symbolTable.Size() > math.MaxUint16 {
+		err = fmt.Errorf("too many variables in program")
+	}
+	if err != nil {
+		c.restore(s)
 		return bytecode.Bytecode{}, err
 	}
 	return c.bytecode(), nil
 }
 
+func (c *Compiler) snapshot() snapshot {
+	root := c.symbolTable.Root()
+	values := make(map[*Symbol]Symbol, len(root.symbols))
+	for _, sym := range root.symbols {
+		values[sym] = *sym
+	}
+	return snapshot{
+		instructions: len(c.instructions),
+		constants:    len(c.constants),
+		handlers:     len(c.handlers),
+		positions:    len(c.positions),
+		symbolTable:  c.symbolTable,
+		symbols:      maps.Clone(root.symbols),
+		values:       values,
+		captures:     maps.Clone(root.captures),
+		size:         *root.size,
+		peak:         *root.peak,
+		frames:       len(c.frames),
+		frame:        *c.frames[len(c.frames)-1],
+		loops:        len(c.loops),
+		labels:       len(c.labels),
+		chains:       len(c.chains),
+		guards:       len(c.guards),
+	}
+}
+
+func (c *Compiler) restore(s snapshot) {
+	c.instructions = c.instructions[:s.instructions]
+	c.constants = c.constants[:s.constants]
+	c.handlers = c.handlers[:s.handlers]
+	c.positions = c.positions[:s.positions]
+
+	c.symbolTable = s.symbolTable
+	root := c.symbolTable.Root()
+	root.symbols = s.symbols
+	root.captures = s.captures
+	*root.size = s.size
+	*root.peak = s.peak
+	for sym, val := range s.values {
+		*sym = val
+	}
+
+	c.frames = c.frames[:s.frames]
+	*c.frames[len(c.frames)-1] = s.frame
+	c.loops = c.loops[:s.loops]
+	c.labels = c.labels[:s.labels]
+	c.chains = c.chains[:s.chains]
+	c.guards = c.guards[:s.guards]
+}
+
 func (c *Compiler) compile(node ast.Node) error {
+	if pos := node.Pos(); pos.IsValid() {
+		outer := c.pos
+		c.pos = pos
+		defer func() { c.pos = outer }()
+	}
+
 	switch node := node.(type) {
 	case *ast.Program:
 		return c.compileProgram(node)
@@ -94,12 +353,66 @@ func (c *Compiler) compile(node ast.Node) error {
 		return c.compileExpressionStatement(node)
 	case *ast.VariableStatement:
 		return c.compileVariableStatement(node)
+	case *ast.IfStatement:
+		return c.compileIfStatement(node)
+	case *ast.WhileStatement:
+		return c.compileWhileStatement(node)
+	case *ast.DoWhileStatement:
+		return c.compileDoWhileStatement(node)
+	case *ast.ForStatement:
+		return c.compileForStatement(node)
+	case *ast.SwitchStatement:
+		return c.compileSwitchStatement(node)
+	case *ast.LabeledStatement:
+		return c.compileLabeledStatement(node)
+	case *ast.BreakStatement:
+		return c.compileBreakStatement(node)
+	case *ast.ContinueStatement:
+		return c.compileContinueStatement(node)
+	case *ast.FunctionDeclaration:
+		return c.compileFunctionDeclaration(node)
+	case *ast.ClassDeclaration:
+		return c.compileClassDeclaration(node)
+	case *ast.ReturnStatement:
+		return c.compileReturnStatement(node)
+	case *ast.ThrowStatement:
+		return c.compileThrowStatement(node)
+	case *ast.TryStatement:
+		return c.compileTryStatement(node)
 	case *ast.PrefixExpression:
 		return c.compilePrefixExpression(node)
+	case *ast.UpdateExpression:
+		return c.compileUpdateExpression(node)
 	case *ast.InfixExpression:
 		return c.compileInfixExpression(node)
+	case *ast.LogicalExpression:
+		return c.compileLogicalExpression(node)
+	case *ast.ConditionalExpression:
+		return c.compileConditionalExpression(node)
+	case *ast.SequenceExpression:
+		return c.compileSequenceExpression(node)
 	case *ast.AssignmentExpression:
 		return c.compileAssignmentExpression(node)
+	case *ast.MemberExpression:
+		return c.compileMemberExpression(node)
+	case *ast.CallExpression:
+		return c.compileCallExpression(node)
+	case *ast.NewExpression:
+		return c.compileNewExpression(node)
+	case *ast.ChainExpression:
+		return c.compileChainExpression(node)
+	case *ast.FunctionExpression:
+		return c.compileFunctionExpression(node)
+	case *ast.ArrowFunctionExpression:
+		return c.compileArrowFunctionExpression(node)
+	case *ast.ThisExpression:
+		return c.compileThisExpression(node)
+	case *ast.SuperExpression:
+		return c.compileSuperExpression(node)
+	case *ast.ClassExpression:
+		return c.compileClassExpression(node)
+	case *ast.PropertyDefinition:
+		return c.compilePropertyDefinition(node)
 	case *ast.NullLiteral:
 		return c.compileNullLiteral(node)
 	case *ast.UndefinedLiteral:
@@ -112,6 +425,12 @@ func (c *Compiler) compile(node ast.Node) error {
 		return c.compileStringLiteral(node)
 	case *ast.IdentifierLiteral:
 		return c.compileIdentifierLiteral(node)
+	case *ast.ArrayLiteral:
+		return c.compileArrayLiteral(node)
+	case *ast.ObjectLiteral:
+		return c.compileObjectLiteral(node)
+	case *stacked:
+		return nil
 	default:
 		return fmt.Errorf("unsupported operand type: %T", node)
 	}
@@ -119,19 +438,44 @@ func (c *Compiler) compile(node ast.Node) error {
 
 func (c *Compiler) bytecode() bytecode.Bytecode {
 	code := bytecode.Bytecode{}
+	offsets := make([]int, 0, len(c.instructions)+1)
 	for _, instruction := range c.instructions {
+		offsets = append(offsets, len(code.Instructions))
 		code.Instructions = append(code.Instructions, instruction...)
 	}
+	offsets = append(offsets, len(code.Instructions))
 	for _, constant := range c.constants {
 		code.Constants = append(code.Constants, constant...)
 	}
+	for _, h := range c.handlers {
+		code.Handlers = append(code.Handlers, bytecode.Handler{Start: offsets[h.start], End: offsets[h.end], Target: offsets[h.target]})
+	}
+	for _, p := range c.positions {
+		code.Positions = append(code.Positions, bytecode.Position{Offset: offsets[p.index], Line: p.pos.Line, Column: p.pos.Column})
+	}
+
+	for _, sym := range c.symbolTable.Root().symbols {
+		if sym.Kind == VAR || sym.Kind == GLOBAL {
+			code.Globals = append(code.Globals, bytecode.Global{Name: sym.Name, Index: sym.Index})
+		}
+	}
+	slices.SortFunc(code.Globals, func(a, b bytecode.Global) int { return a.Index - b.Index })
 
 	c.instructions = nil
 	c.constants = nil
+	c.handlers = nil
+	c.positions = nil
 	return code
 }
 
 func (c *Compiler) compileProgram(node *ast.Program) error {
+	c.frames[0].strict = strict(node.Statements)
+	if c.frames[0].strict {
+		c.emit(bytecode.STRICT)
+	}
+	if err := c.hoist(node.Statements); err != nil {
+		return err
+	}
 	for _, n := range node.Statements {
 		if err := c.compile(n); err != nil {
 			return err
@@ -140,146 +484,2358 @@ func (c *Compiler) compileProgram(node *ast.Program) error {
 	return nil
 }
 
-func (c *Compiler) compileEmptyStatement(_ *ast.EmptyStatement) error {
-	return nil
+func (c *Compiler) compileEmptyStatement(_ *ast.EmptyStatement) error {
+	return nil
+}
+
+func (c *Compiler) compileBlockStatement(node *ast.BlockStatement) error {
+	return c.scope(node.Statements, func() error {
+		for _, n := range node.Statements {
+			if err := c.compile(n); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (c *Compiler) compileExpressionStatement(node *ast.ExpressionStatement) error {
+	if err := c.compile(node.Expression); err != nil {
+		return err
+	}
+	c.emit(bytecode.POP)
+	return nil
+}
+
+func (c *Compiler) compileVariableStatement(node *ast.VariableStatement) error {
+	switch node.Token.Type {
+	case token.VAR:
+		for _, n := range node.Right {
+			name, init := c.declarator(n)
+			if _, err := c.symbolTable.Declare(name.Value, VAR); err != nil {
+				return err
+			}
+			if init == nil {
+				continue
+			}
+			if err := c.compile(n); err != nil {
+				return err
+			}
+			c.emit(bytecode.POP)
+		}
+		return nil
+	case token.LET, token.CONST:
+		for _, n := range node.Right {
+			name, init := c.declarator(n)
+			sym, ok := c.symbolTable.symbols[name.Value]
+			if !ok {
+				var err error
+				if sym, err = c.symbolTable.Declare(name.Value, c.kind(node.Token)); err != nil {
+					return err
+				}
+			}
+
+			typ := interpreter.UNDEFINED
+			if init != nil {
+				typ = c.getType(init)
+				if err := c.compileValue(init, name.Value); err != nil {
+					return err
+				}
+			} else {
+				c.emit(bytecode.UNDEFLOAD)
+			}
+			sym.Type = typ
+			sym.Initialized = true
+			if err := c.write(sym, c.symbolTable); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid variable token type: %s", node.Token.Type)
+	}
+}
+
+// scope compiles fn in a block scope holding the lexical declarations of the statements.
+func (c *Compiler) scope(statements []ast.Statement, fn func() error) error {
+	c.symbolTable = NewEnclosedSymbolTable(c.symbolTable)
+	err := c.hoist(statements)
+	if err == nil {
+		err = fn()
+	}
+	c.symbolTable.Free()
+	c.symbolTable = c.symbolTable.Outer()
+	return err
+}
+
+// hoist declares the lexical declarations of the statements in the temporal dead zone and initializes functions.
+func (c *Compiler) hoist(statements []ast.Statement) error {
+	if c.symbolTable == c.symbolTable.Frame() {
+		if err := c.vars(statements); err != nil {
+			return err
+		}
+	}
+
+	var functions []*ast.FunctionDeclaration
+	var symbols []*Symbol
+	for _, stmt := range statements {
+		switch node := stmt.(type) {
+		case *ast.VariableStatement:
+			if node.Token.Type == token.VAR {
+				continue
+			}
+			for _, n := range node.Right {
+				name, _ := c.declarator(n)
+				sym, err := c.symbolTable.Declare(name.Value, c.kind(node.Token))
+				if err != nil {
+					return err
+				}
+				sym.Type = interpreter.UNDEFINED
+				c.emit(bytecode.SLTCLEAR, uint64(sym.Index))
+			}
+		case *ast.ClassDeclaration:
+			sym, err := c.symbolTable.Declare(node.Class.Name.Value, LET)
+			if err != nil {
+				return err
+			}
+			sym.Type = interpreter.UNDEFINED
+			c.emit(bytecode.SLTCLEAR, uint64(sym.Index))
+		case *ast.FunctionDeclaration:
+			kind := LET
+			if c.symbolTable == c.symbolTable.Frame() {
+				kind = VAR
+			}
+			sym, err := c.symbolTable.Declare(node.Function.Name.Value, kind)
+			if err != nil {
+				return err
+			}
+			sym.Type = interpreter.UNKNOWN
+			sym.Initialized = true
+			if kind == LET && sym.Captured {
+				c.emit(bytecode.SLTCLEAR, uint64(sym.Index))
+			}
+			functions = append(functions, node)
+			symbols = append(symbols, sym)
+		default:
+		}
+	}
+
+	for i, node := range functions {
+		if err := c.compileFunction(node.Function, node.Function.Name.Value, "", interpreter.CONSTRUCTOR, nil); err != nil {
+			return err
+		}
+		if err := c.write(symbols[i], c.symbolTable); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vars declares the var declarations of the statements in the frame.
+func (c *Compiler) vars(statements []ast.Statement) error {
+	var err error
+	for _, stmt := range statements {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FunctionExpression:
+				return false
+			case *ast.VariableStatement:
+				if n.Token.Type != token.VAR {
+					break
+				}
+				for _, exp := range n.Right {
+					name, _ := c.declarator(exp)
+					var sym *Symbol
+					if sym, err = c.symbolTable.Declare(name.Value, VAR); err != nil {
+						return false
+					}
+					if !sym.Initialized {
+						sym.Initialized = true
+						if err = c.read(sym, c.symbolTable); err == nil {
+							err = c.write(sym, c.symbolTable)
+						}
+					}
+				}
+			default:
+			}
+			return err == nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// captures returns the names referenced by the functions nested in the nodes.
+func (c *Compiler) captures(nodes ...ast.Node) []string {
+	var names []string
+	for _, node := range nodes {
+		ast.Inspect(node, func(fn ast.Node) bool {
+			switch fn.(type) {
+			case *ast.FunctionExpression, *ast.ArrowFunctionExpression, *ast.PropertyDefinition:
+			default:
+				return true
+			}
+			ast.Inspect(fn, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.IdentifierLiteral:
+					names = append(names, n.Value)
+				case *ast.PrivateIdentifier:
+					names = append(names, n.Value)
+				case *ast.ThisExpression, *ast.SuperExpression:
+					names = append(names, "this")
+				default:
+				}
+				return true
+			})
+			return false
+		})
+	}
+	return names
+}
+
+// lexical reports whether the nodes refer to the implicit binding of their frame named after the name.
+func (c *Compiler) lexical(name string, nodes ...ast.Node) bool {
+	found := false
+	for _, node := range nodes {
+		ast.Inspect(node, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FunctionExpression:
+				return false
+			case *ast.ClassExpression:
+				var nodes []ast.Node
+				if n.SuperClass != nil {
+					nodes = append(nodes, n.SuperClass)
+				}
+				for _, member := range n.Members {
+					switch member := member.(type) {
+					case *ast.MethodDefinition:
+						if member.Computed {
+							nodes = append(nodes, member.Key)
+						}
+					case *ast.PropertyDefinition:
+						if member.Computed {
+							nodes = append(nodes, member.Key)
+						}
+					default:
+					}
+				}
+				found = found || c.lexical(name, nodes...)
+				return false
+			case *ast.CallExpression:
+				if _, ok := n.Callee.(*ast.SuperExpression); !ok {
+					break
+				}
+				for _, arg := range n.Arguments {
+					found = found || c.lexical(name, arg)
+				}
+				return false
+			case *ast.ThisExpression, *ast.SuperExpression:
+				found = found || name == "this"
+			case *ast.IdentifierLiteral:
+				found = found || n.Value == name
+			default:
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// check guards a reference to a symbol that may be in the temporal dead zone.
+func (c *Compiler) check(sym *Symbol, t *SymbolTable) {
+	if sym.Initialized && (sym.Kind == VAR || c.storage(sym, t) != global) {
+		return
+	}
+	if sym.Kind == GLOBAL {
+		offset, size := c.store([]byte(sym.Name))
+		c.emit(bytecode.GLBCHECK, uint64(sym.Index), offset, size)
+		return
+	}
+	switch c.storage(sym, t) {
+	case slot:
+		offset, size := c.store([]byte(sym.Name))
+		c.emit(bytecode.SLTCHECK, uint64(sym.Index), offset, size)
+	case cell:
+		offset, size := c.store([]byte(sym.Name))
+		c.emit(bytecode.CELLCHECK, uint64(sym.Index), offset, size)
+	case global:
+		offset, size := c.store([]byte(sym.Name))
+		c.emit(bytecode.GLBHOLE, uint64(sym.Index), offset, size)
+	case upvalue:
+		index := c.upvalue(len(c.frames)-1, sym, t)
+		offset, size := c.store([]byte(sym.Name))
+		c.emit(bytecode.UPVCHECK, uint64(index), offset, size)
+	default:
+	}
+}
+
+func (c *Compiler) declarator(node ast.Expression) (*ast.IdentifierLiteral, ast.Expression) {
+	switch node := node.(type) {
+	case *ast.AssignmentExpression:
+		name, _ := node.Left.(*ast.IdentifierLiteral)
+		return name, node.Right
+	case *ast.IdentifierLiteral:
+		return node, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (c *Compiler) kind(tok token.Token) Kind {
+	switch tok.Type {
+	case token.LET:
+		return LET
+	case token.CONST:
+		return CONST
+	default:
+		return VAR
+	}
+}
+
+func (c *Compiler) compileIfStatement(node *ast.IfStatement) error {
+	typ := c.getType(node.Condition)
+	if err := c.compile(node.Condition); err != nil {
+		return err
+	}
+	if err := c.cast(typ, interpreter.BOOL); err != nil {
+		return err
+	}
+	alternative := c.emit(bytecode.JMPF, 0)
+
+	types := c.symbolTable.Types()
+	if err := c.compile(node.Consequence); err != nil {
+		return err
+	}
+
+	if node.Alternative == nil {
+		c.symbolTable.Merge(types)
+		c.patch(alternative, uint64(c.offset()))
+		return nil
+	}
+
+	end := c.emit(bytecode.JMP, 0)
+	c.patch(alternative, uint64(c.offset()))
+
+	consequence := c.symbolTable.Types()
+	c.symbolTable.Restore(types)
+	if err := c.compile(node.Alternative); err != nil {
+		return err
+	}
+	c.symbolTable.Merge(consequence)
+
+	c.patch(end, uint64(c.offset()))
+	return nil
+}
+
+func (c *Compiler) compileWhileStatement(node *ast.WhileStatement) error {
+	return c.compileLoop(func(l *loop) error {
+		head := c.offset()
+
+		typ := c.getType(node.Condition)
+		if err := c.compile(node.Condition); err != nil {
+			return err
+		}
+		if err := c.cast(typ, interpreter.BOOL); err != nil {
+			return err
+		}
+		l.breaks = append(l.breaks, c.branch(bytecode.JMPF))
+
+		if err := c.compile(node.Body); err != nil {
+			return err
+		}
+
+		c.next(l, head)
+		c.emit(bytecode.JMP, uint64(head))
+		return nil
+	})
+}
+
+func (c *Compiler) compileDoWhileStatement(node *ast.DoWhileStatement) error {
+	return c.compileLoop(func(l *loop) error {
+		head := c.offset()
+
+		if err := c.compile(node.Body); err != nil {
+			return err
+		}
+		c.next(l, c.offset())
+
+		typ := c.getType(node.Condition)
+		if err := c.compile(node.Condition); err != nil {
+			return err
+		}
+		if err := c.cast(typ, interpreter.BOOL); err != nil {
+			return err
+		}
+		c.emit(bytecode.JMPT, uint64(head))
+		l.exits = append(l.exits, c.symbolTable.Types())
+		return nil
+	})
+}
+
+func (c *Compiler) compileForStatement(node *ast.ForStatement) error {
+	var init []ast.Statement
+	if node.Init != nil {
+		init = append(init, node.Init)
+	}
+	return c.scope(init, func() error {
+		return c.compileForLoop(node)
+	})
+}
+
+func (c *Compiler) compileForLoop(node *ast.ForStatement) error {
+	var bindings []*Symbol
+	if node.Init != nil {
+		if err := c.compile(node.Init); err != nil {
+			return err
+		}
+		if init, ok := node.Init.(*ast.VariableStatement); ok && init.Token.Type == token.LET {
+			for _, n := range init.Right {
+				name, _ := c.declarator(n)
+				bindings = append(bindings, c.symbolTable.symbols[name.Value])
+			}
+		}
+		c.rebind(bindings)
+	}
+
+	return c.compileLoop(func(l *loop) error {
+		head := c.offset()
+
+		if node.Condition != nil {
+			typ := c.getType(node.Condition)
+			if err := c.compile(node.Condition); err != nil {
+				return err
+			}
+			if err := c.cast(typ, interpreter.BOOL); err != nil {
+				return err
+			}
+			l.breaks = append(l.breaks, c.branch(bytecode.JMPF))
+		}
+
+		if err := c.compile(node.Body); err != nil {
+			return err
+		}
+
+		c.next(l, c.offset())
+		c.rebind(bindings)
+		if node.Update != nil {
+			if err := c.compile(node.Update); err != nil {
+				return err
+			}
+			c.emit(bytecode.POP)
+		}
+		c.emit(bytecode.JMP, uint64(head))
+		return nil
+	})
+}
+
+// rebind copies the captured bindings into fresh cells for the next iteration.
+func (c *Compiler) rebind(bindings []*Symbol) {
+	for _, sym := range bindings {
+		if c.storage(sym, c.symbolTable) == cell {
+			c.emit(bytecode.SLTBOX, uint64(sym.Index))
+		}
+	}
+}
+
+func (c *Compiler) compileSwitchStatement(node *ast.SwitchStatement) error {
+	l := &loop{labels: c.labels, breakable: true}
+	c.labels = nil
+
+	typ := c.getType(node.Discriminant)
+	if err := c.compile(node.Discriminant); err != nil {
+		return err
+	}
+
+	var statements []ast.Statement
+	for _, n := range node.Cases {
+		statements = append(statements, n.Consequent...)
+	}
+	return c.scope(statements, func() error {
+		return c.compileSwitchCases(node, l, typ)
+	})
+}
+
+func (c *Compiler) compileSwitchCases(node *ast.SwitchStatement, l *loop, typ interpreter.Type) error {
+	jumps := make([][]int, len(node.Cases))
+	var fallback []int
+	if low, table, ok := c.table(typ, node.Cases); ok {
+		c.emit(bytecode.I32SWITCH, uint64(uint32(low)), uint64(len(table)))
+		fallback = append(fallback, c.emit(bytecode.JMP, 0))
+		for _, i := range table {
+			if i < 0 {
+				fallback = append(fallback, c.emit(bytecode.JMP, 0))
+			} else {
+				jumps[i] = append(jumps[i], c.emit(bytecode.JMP, 0))
+			}
+		}
+	} else {
+		for i, n := range node.Cases {
+			if n.Test == nil {
+				continue
+			}
+			c.emit(bytecode.DUP)
+			if err := c.compileComparison(token.IDENTITY_EQUAL, typ, n.Test); err != nil {
+				return err
+			}
+			next := c.emit(bytecode.JMPF, 0)
+			c.emit(bytecode.POP)
+			jumps[i] = append(jumps[i], c.emit(bytecode.JMP, 0))
+			c.patch(next, uint64(c.offset()))
+		}
+		c.emit(bytecode.POP)
+		fallback = append(fallback, c.emit(bytecode.JMP, 0))
+	}
+
+	types := c.symbolTable.Types()
+
+	c.loops = append(c.loops, l)
+	err := func() error {
+		for i, n := range node.Cases {
+			c.symbolTable.Merge(types)
+			for _, j := range jumps[i] {
+				c.patch(j, uint64(c.offset()))
+			}
+			if n.Test == nil {
+				for _, j := range fallback {
+					c.patch(j, uint64(c.offset()))
+				}
+				fallback = nil
+			}
+
+			for _, stmt := range n.Consequent {
+				if err := c.compile(stmt); err != nil {
+					return err
+				}
+			}
+
+			for _, sym := range c.symbolTable.symbols {
+				sym.Initialized = false
+			}
+		}
+		return nil
+	}()
+	c.loops = c.loops[:len(c.loops)-1]
+	if err != nil {
+		return err
+	}
+
+	l.exits = append(l.exits, c.symbolTable.Types())
+	if fallback != nil {
+		for _, j := range fallback {
+			c.patch(j, uint64(c.offset()))
+		}
+		l.exits = append(l.exits, types)
+	}
+	c.exit(l)
+	return nil
+}
+
+func (c *Compiler) compileLabeledStatement(node *ast.LabeledStatement) error {
+	label := node.Label.Value
+	if slices.Contains(c.labels, label) {
+		return fmt.Errorf("label '%s' has already been declared", label)
+	}
+	for _, l := range c.loops {
+		if slices.Contains(l.labels, label) {
+			return fmt.Errorf("label '%s' has already been declared", label)
+		}
+	}
+	c.labels = append(c.labels, label)
+
+	switch node.Body.(type) {
+	case *ast.WhileStatement, *ast.DoWhileStatement, *ast.ForStatement, *ast.SwitchStatement, *ast.LabeledStatement:
+		return c.compile(node.Body)
+	}
+
+	l := &loop{labels: c.labels}
+	c.labels = nil
+
+	c.loops = append(c.loops, l)
+	err := c.compile(node.Body)
+	c.loops = c.loops[:len(c.loops)-1]
+	if err != nil {
+		return err
+	}
+
+	l.exits = append(l.exits, c.symbolTable.Types())
+	c.exit(l)
+	return nil
+}
+
+func (c *Compiler) compileBreakStatement(node *ast.BreakStatement) error {
+	l, err := c.target(node.Token, node.Label)
+	if err != nil {
+		return err
+	}
+	if err := c.leave(slices.Index(c.loops, l)); err != nil {
+		return err
+	}
+	l.breaks = append(l.breaks, c.branch(bytecode.JMP))
+	return nil
+}
+
+func (c *Compiler) compileContinueStatement(node *ast.ContinueStatement) error {
+	l, err := c.target(node.Token, node.Label)
+	if err != nil {
+		return err
+	}
+	if err := c.leave(slices.Index(c.loops, l)); err != nil {
+		return err
+	}
+	l.continues = append(l.continues, c.branch(bytecode.JMP))
+	return nil
+}
+
+func (c *Compiler) compileFunctionDeclaration(_ *ast.FunctionDeclaration) error {
+	return nil
+}
+
+func (c *Compiler) compileClassDeclaration(node *ast.ClassDeclaration) error {
+	name := node.Class.Name.Value
+	sym, ok := c.symbolTable.symbols[name]
+	if !ok {
+		var err error
+		if sym, err = c.symbolTable.Declare(name, LET); err != nil {
+			return err
+		}
+	}
+	if err := c.compileClass(node.Class, name); err != nil {
+		return err
+	}
+	sym.Type = interpreter.UNKNOWN
+	sym.Initialized = true
+	return c.write(sym, c.symbolTable)
+}
+
+func (c *Compiler) compileReturnStatement(node *ast.ReturnStatement) error {
+	if c.symbolTable.Frame() == c.symbolTable.Root() {
+		return fmt.Errorf("illegal return statement")
+	}
+	if node.Argument != nil {
+		if err := c.compile(node.Argument); err != nil {
+			return err
+		}
+	} else {
+		c.emit(bytecode.UNDEFLOAD)
+	}
+	if !slices.ContainsFunc(c.guards, func(g *guard) bool { return g.finalizer != nil }) {
+		c.emit(bytecode.RETURN)
+		return nil
+	}
+
+	return c.scope(nil, func() error {
+		sym := c.symbolTable.Define("%result")
+		sym.Type = interpreter.UNKNOWN
+		if err := c.write(sym, c.symbolTable); err != nil {
+			return err
+		}
+		if err := c.leave(-1); err != nil {
+			return err
+		}
+		if err := c.read(sym, c.symbolTable); err != nil {
+			return err
+		}
+		c.emit(bytecode.RETURN)
+		return nil
+	})
+}
+
+func (c *Compiler) compileThrowStatement(node *ast.ThrowStatement) error {
+	if err := c.compile(node.Argument); err != nil {
+		return err
+	}
+	c.emit(bytecode.THROW)
+	return nil
+}
+
+// compileTryStatement inlines the finalizer on every path leaving the block and the catch clause.
+func (c *Compiler) compileTryStatement(node *ast.TryStatement) error {
+	types := c.symbolTable.Types()
+	writes := map[*Symbol]bool{}
+
+	var jumps []int
+	var exits []map[*Symbol]interpreter.Type
+	exit := func() error {
+		if node.Finalizer != nil {
+			if err := c.compile(node.Finalizer); err != nil {
+				return err
+			}
+		}
+		exits = append(exits, c.symbolTable.Types())
+		jumps = append(jumps, c.emit(bytecode.JMP, 0))
+		return nil
+	}
+
+	g := &guard{finalizer: node.Finalizer, loops: len(c.loops), start: len(c.instructions), writes: writes}
+	if err := c.protect(g, func() error { return c.compile(node.Block) }); err != nil {
+		return err
+	}
+	end := len(c.instructions)
+	if err := exit(); err != nil {
+		return err
+	}
+
+	if node.Handler != nil {
+		c.handle(g, end, types)
+
+		h := &guard{finalizer: node.Finalizer, loops: len(c.loops), start: len(c.instructions), writes: writes}
+		if node.Finalizer == nil {
+			h = nil
+		}
+		if err := c.protect(h, func() error { return c.compileCatchClause(node.Handler) }); err != nil {
+			return err
+		}
+		if h != nil {
+			g, end = h, len(c.instructions)
+		}
+		if err := exit(); err != nil {
+			return err
+		}
+	}
+
+	if node.Finalizer != nil {
+		c.handle(g, end, types)
+		err := c.scope(nil, func() error {
+			sym := c.symbolTable.Define("%exception")
+			sym.Type = interpreter.UNKNOWN
+			if err := c.write(sym, c.symbolTable); err != nil {
+				return err
+			}
+			if err := c.compile(node.Finalizer); err != nil {
+				return err
+			}
+			if err := c.read(sym, c.symbolTable); err != nil {
+				return err
+			}
+			c.emit(bytecode.THROW)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, j := range jumps {
+		c.patch(j, uint64(c.offset()))
+	}
+	c.symbolTable.Restore(exits[0])
+	for _, types := range exits[1:] {
+		c.symbolTable.Merge(types)
+	}
+	return nil
+}
+
+func (c *Compiler) compileCatchClause(node *ast.CatchClause) error {
+	return c.scope(node.Body.Statements, func() error {
+		if node.Param == nil {
+			c.emit(bytecode.POP)
+		} else {
+			sym, err := c.symbolTable.Declare(node.Param.Value, LET)
+			if err != nil {
+				return err
+			}
+			sym.Type = interpreter.UNKNOWN
+			c.emit(bytecode.SLTCLEAR, uint64(sym.Index))
+			if err := c.write(sym, c.symbolTable); err != nil {
+				return err
+			}
+			sym.Initialized = true
+		}
+		for _, n := range node.Body.Statements {
+			if err := c.compile(n); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// protect compiles fn under the guard, whose writes are recorded until it is done.
+func (c *Compiler) protect(g *guard, fn func() error) error {
+	if g == nil {
+		return fn()
+	}
+	c.guards = append(c.guards, g)
+	err := fn()
+	c.guards = c.guards[:len(c.guards)-1]
+	return err
+}
+
+// handle directs the exceptions thrown under the guard to the current offset.
+func (c *Compiler) handle(g *guard, end int, types map[*Symbol]interpreter.Type) {
+	target := len(c.instructions)
+	start := g.start
+	for _, gap := range g.gaps {
+		if start < gap[0] {
+			c.handlers = append(c.handlers, handler{start: start, end: gap[0], target: target})
+		}
+		start = gap[1]
+	}
+	if start < end {
+		c.handlers = append(c.handlers, handler{start: start, end: end, target: target})
+	}
+
+	c.symbolTable.Restore(types)
+	for sym := range g.writes {
+		sym.Type = interpreter.UNKNOWN
+	}
+}
+
+// leave inlines the finalizers a jump out of the loop at the depth runs.
+func (c *Compiler) leave(depth int) error {
+	guards, loops, labels := c.guards, c.loops, c.labels
+	defer func() {
+		c.guards, c.loops, c.labels = guards, loops, labels
+	}()
+
+	top := len(guards)
+	for i := len(guards) - 1; i >= 0 && guards[i].loops > depth; i-- {
+		g := guards[i]
+		if g.finalizer == nil {
+			continue
+		}
+		for _, g := range guards[i:top] {
+			g.suspend(len(c.instructions))
+		}
+		top = i
+
+		c.guards, c.loops, c.labels = slices.Clip(guards[:i]), slices.Clip(loops[:g.loops]), nil
+		if err := c.compile(g.finalizer); err != nil {
+			return err
+		}
+	}
+	for _, g := range guards[top:] {
+		g.resume(len(c.instructions))
+	}
+	return nil
+}
+
+// compileLoop compiles a loop until the types of symbols at its head reach a fixpoint.
+func (c *Compiler) compileLoop(fn func(l *loop) error) error {
+	labels := c.labels
+	c.labels = nil
+
+	instructions := len(c.instructions)
+	constants := len(c.constants)
+
+	for {
+		types := c.symbolTable.Types()
+
+		l := &loop{labels: labels, breakable: true, iteration: true}
+		c.loops = append(c.loops, l)
+		err := fn(l)
+		c.loops = c.loops[:len(c.loops)-1]
+		if err != nil {
+			return err
+		}
+
+		c.symbolTable.Merge(types)
+		if !c.symbolTable.Changed(types) {
+			if len(l.exits) == 0 && len(l.breaks) == 0 {
+				l.exits = append(l.exits, types)
+			}
+			c.exit(l)
+			return nil
+		}
+
+		c.instructions = c.instructions[:instructions]
+		c.constants = c.constants[:constants]
+		c.handlers = slices.DeleteFunc(c.handlers, func(h handler) bool { return h.start >= instructions })
+		c.positions = slices.DeleteFunc(c.positions, func(p position) bool { return p.index >= instructions })
+		for _, l := range c.loops {
+			l.truncate(instructions)
+		}
+		for _, g := range c.guards {
+			g.gaps = slices.DeleteFunc(g.gaps, func(gap [2]int) bool { return gap[0] >= instructions })
+		}
+	}
+}
+
+// target resolves the statement a break or continue jumps out of.
+func (c *Compiler) target(tok token.Token, label *ast.IdentifierLiteral) (*loop, error) {
+	for i := len(c.loops) - 1; i >= 0; i-- {
+		l := c.loops[i]
+		if label == nil {
+			if (tok.Type == token.BREAK && l.breakable) || l.iteration {
+				return l, nil
+			}
+			continue
+		}
+		if !slices.Contains(l.labels, label.Value) {
+			continue
+		}
+		if tok.Type == token.CONTINUE && !l.iteration {
+			return nil, fmt.Errorf("illegal continue statement: '%s' does not denote an iteration statement", label.Value)
+		}
+		return l, nil
+	}
+	if label != nil {
+		return nil, fmt.Errorf("undefined label '%s'", label.Value)
+	}
+	return nil, fmt.Errorf("illegal %s statement", tok.Literal)
+}
+
+// table returns the jump table of dense INT32 cases, where a negative entry selects the default.
+func (c *Compiler) table(typ interpreter.Type, cases []*ast.SwitchCase) (int32, []int, bool) {
+	if typ != interpreter.INT32 {
+		return 0, nil, false
+	}
+
+	values := map[int32]int{}
+	low, high := int32(math.MaxInt32), int32(math.MinInt32)
+	for i, n := range cases {
+		if n.Test == nil {
+			continue
+		}
+		lit, ok := n.Test.(*ast.NumberLiteral)
+		if !ok || c.getType(lit) != interpreter.INT32 {
+			return 0, nil, false
+		}
+		val := int32(lit.Value)
+		if _, ok := values[val]; !ok {
+			values[val] = i
+		}
+		low = min(low, val)
+		high = max(high, val)
+	}
+	if len(values) < 4 || int64(high)-int64(low)+1 > 2*int64(len(values)) {
+		return 0, nil, false
+	}
+
+	table := make([]int, int(high-low)+1)
+	for i := range table {
+		table[i] = -1
+	}
+	for val, i := range values {
+		table[val-low] = i
+	}
+	return low, table, true
+}
+
+// next joins the types of the paths continuing the loop.
+func (c *Compiler) next(l *loop, offset int) {
+	for _, b := range l.continues {
+		c.symbolTable.Merge(b.types)
+		c.patch(b.index, uint64(offset))
+	}
+}
+
+// exit joins the types of the paths leaving the statement.
+func (c *Compiler) exit(l *loop) {
+	exits := l.exits
+	for _, b := range l.breaks {
+		exits = append(exits, b.types)
+		c.patch(b.index, uint64(c.offset()))
+	}
+	c.symbolTable.Restore(exits[0])
+	for _, types := range exits[1:] {
+		c.symbolTable.Merge(types)
+	}
+}
+
+func (c *Compiler) branch(op bytecode.Opcode) branch {
+	return branch{index: c.emit(op, 0), types: c.symbolTable.Types()}
+}
+
+func (g *guard) suspend(at int) {
+	g.gaps = append(g.gaps, [2]int{at, at})
+}
+
+func (g *guard) resume(at int) {
+	g.gaps[len(g.gaps)-1][1] = at
+}
+
+func (l *loop) truncate(n int) {
+	l.breaks = slices.DeleteFunc(l.breaks, func(b branch) bool { return b.index >= n })
+	l.continues = slices.DeleteFunc(l.continues, func(b branch) bool { return b.index >= n })
+}
+
+func (c *Compiler) compilePrefixExpression(node *ast.PrefixExpression) error {
+	switch node.Token.Type {
+	case token.TYPEOF:
+		if ident, ok := node.Right.(*ast.IdentifierLiteral); ok {
+			if err := c.identifier(ident, false); err != nil {
+				return err
+			}
+		} else if err := c.compile(node.Right); err != nil {
+			return err
+		}
+		c.emit(bytecode.ANYTYPEOF)
+		return nil
+	case token.VOID:
+		if err := c.compile(node.Right); err != nil {
+			return err
+		}
+		c.emit(bytecode.POP)
+		c.emit(bytecode.UNDEFLOAD)
+		return nil
+	case token.DELETE:
+		return c.compileDeleteExpression(node)
+	default:
+	}
+
+	if n, ok := node.Right.(*ast.NumberLiteral); ok && n.Value == 0 && node.Token.Type == token.MINUS {
+		c.emit(bytecode.F64LOAD, math.Float64bits(math.Copysign(0, -1)))
+		return nil
+	}
+
+	typ := c.getType(node)
+	right := c.getType(node.Right)
+
+	if err := c.compile(node.Right); err != nil {
+		return err
+	}
+	if err := c.cast(right, typ); err != nil {
+		return err
+	}
+
+	switch node.Token.Type {
+	case token.NOT:
+		c.emit(bytecode.BOOLNOT)
+		return nil
+	case token.BIT_NOT:
+		c.emit(bytecode.I32NOT)
+		return nil
+	case token.PLUS, token.MINUS:
+		if node.Token.Type == token.MINUS {
+			switch typ {
+			case interpreter.INT32:
+				c.emit(bytecode.I32LOAD, uint64(0xFFFFFFFFFFFFFFFF))
+				c.emit(bytecode.I32MUL)
+			case interpreter.FLOAT64:
+				c.emit(bytecode.F64LOAD, math.Float64bits(-1))
+				c.emit(bytecode.F64MUL)
+			default:
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported operator '%s' for types %v", node.Token.Type, right)
+}
+
+func (c *Compiler) compileDeleteExpression(node *ast.PrefixExpression) error {
+	if operand, ok := node.Right.(*ast.IdentifierLiteral); ok {
+		_, ok := c.symbolTable.Resolve(operand.Value)
+		c.emit(bytecode.BOOLLOAD, uint64(interpreter.NewBool(!ok)))
+		return nil
+	}
+
+	if operand, ok := node.Right.(*ast.MemberExpression); ok {
+		if _, ok := operand.Object.(*ast.SuperExpression); ok {
+			return fmt.Errorf("unsupported reference to super property '%s'", operand.String())
+		}
+		if _, ok := operand.Property.(*ast.PrivateIdentifier); ok {
+			return fmt.Errorf("private fields can not be deleted")
+		}
+		if err := c.compile(operand.Object); err != nil {
+			return err
+		}
+		if err := c.compileProperty(operand); err != nil {
+			return err
+		}
+		c.emit(bytecode.ANYDELETE)
+		return nil
+	}
+
+	if err := c.compile(node.Right); err != nil {
+		return err
+	}
+	c.emit(bytecode.POP)
+	c.emit(bytecode.BOOLLOAD, 1)
+	return nil
+}
+
+func (c *Compiler) compileUpdateExpression(node *ast.UpdateExpression) error {
+	if operand, ok := node.Operand.(*ast.MemberExpression); ok {
+		return c.compileMemberUpdate(node, operand)
+	}
+	operand, ok := node.Operand.(*ast.IdentifierLiteral)
+	if !ok {
+		return fmt.Errorf("invalid update operand: %s", node.Operand.String())
+	}
+	if sym, ok := c.symbolTable.Resolve(operand.Value); ok && sym.Kind == CONST {
+		return fmt.Errorf("assignment to constant variable '%s'", sym.Name)
+	}
+
+	typ := c.getType(node)
+	from := c.getType(operand)
+	if err := c.compile(operand); err != nil {
+		return err
+	}
+	if err := c.cast(from, typ); err != nil {
+		return err
+	}
+	if !node.Prefix {
+		c.emit(bytecode.DUP)
+	}
+
+	switch typ {
+	case interpreter.INT32:
+		c.emit(bytecode.I32LOAD, 1)
+		if node.Token.Type == token.PLUS_PLUS {
+			c.emit(bytecode.I32ADD)
+		} else {
+			c.emit(bytecode.I32SUB)
+		}
+	default:
+		c.emit(bytecode.F64LOAD, math.Float64bits(1))
+		if node.Token.Type == token.PLUS_PLUS {
+			c.emit(bytecode.F64ADD)
+		} else {
+			c.emit(bytecode.F64SUB)
+		}
+	}
+
+	sym, t, _ := c.symbolTable.Lookup(operand.Value)
+	sym.Type = typ
+
+	if err := c.write(sym, t); err != nil {
+		return err
+	}
+	if node.Prefix {
+		return c.read(sym, t)
+	}
+	return nil
+}
+
+// compileMemberUpdate evaluates the object and the key only once.
+func (c *Compiler) compileMemberUpdate(node *ast.UpdateExpression, operand *ast.MemberExpression) error {
+	if err := c.compile(operand.Object); err != nil {
+		return err
+	}
+	if err := c.compileProperty(operand); err != nil {
+		return err
+	}
+	c.emit(bytecode.DUP2)
+	c.get(operand)
+	c.emit(bytecode.ANYTOF64)
+	if !node.Prefix {
+		c.emit(bytecode.INSERT, 2)
+	}
+
+	c.emit(bytecode.F64LOAD, math.Float64bits(1))
+	if node.Token.Type == token.PLUS_PLUS {
+		c.emit(bytecode.F64ADD)
+	} else {
+		c.emit(bytecode.F64SUB)
+	}
+	c.set(operand)
+	if !node.Prefix {
+		c.emit(bytecode.POP)
+	}
+	return nil
+}
+
+func (c *Compiler) compileInfixExpression(node *ast.InfixExpression) error {
+	switch node.Token.Type {
+	case token.EQUAL, token.NOT_EQUAL, token.IDENTITY_EQUAL, token.IDENTITY_NOT_EQUAL,
+		token.LESS_THAN, token.GREATER_THAN, token.LESS_THAN_OR_EQUAL, token.GREATER_THAN_OR_EQUAL:
+		return c.compileComparisonExpression(node)
+	case token.INSTANCEOF:
+		if err := c.compile(node.Left); err != nil {
+			return err
+		}
+		if err := c.compile(node.Right); err != nil {
+			return err
+		}
+		c.emit(bytecode.ANYINSTANCEOF)
+		return nil
+	}
+	if _, ok := bitwises[node.Token.Type]; ok {
+		return c.compileBitwiseExpression(node)
+	}
+
+	typ := c.getType(node)
+	left := c.getType(node.Left)
+	right := c.getType(node.Right)
+
+	if typ == interpreter.STRING && node.Token.Type == token.PLUS && (left == interpreter.UNKNOWN || right == interpreter.UNKNOWN) {
+		if err := c.compile(node.Left); err != nil {
+			return err
+		}
+		if err := c.compile(node.Right); err != nil {
+			return err
+		}
+		c.emit(bytecode.ANYADD)
+		return nil
+	}
+
+	if err := c.compile(node.Left); err != nil {
+		return err
+	}
+	if err := c.cast(left, typ); err != nil {
+		return err
+	}
+
+	if err := c.compile(node.Right); err != nil {
+		return err
+	}
+	if err := c.cast(right, typ); err != nil {
+		return err
+	}
+
+	switch typ {
+	case interpreter.INT32:
+		switch node.Token.Type {
+		case token.PLUS:
+			c.emit(bytecode.I32ADD)
+			return nil
+		case token.MINUS:
+			c.emit(bytecode.I32SUB)
+			return nil
+		case token.MULTIPLY:
+			c.emit(bytecode.I32MUL)
+			return nil
+		}
+	case interpreter.FLOAT64:
+		switch node.Token.Type {
+		case token.PLUS:
+			c.emit(bytecode.F64ADD)
+			return nil
+		case token.MINUS:
+			c.emit(bytecode.F64SUB)
+			return nil
+		case token.MULTIPLY:
+			c.emit(bytecode.F64MUL)
+			return nil
+		case token.DIVIDE:
+			c.emit(bytecode.F64DIV)
+			return nil
+		case token.MODULUS:
+			c.emit(bytecode.F64MOD)
+			return nil
+		case token.EXPONENT:
+			c.emit(bytecode.F64POW)
+			return nil
+		}
+	case interpreter.STRING:
+		switch node.Token.Type {
+		case token.PLUS:
+			c.emit(bytecode.STRADD)
+			return nil
+		}
+	case interpreter.UNKNOWN:
+		switch node.Token.Type {
+		case token.PLUS:
+			c.emit(bytecode.ANYADD)
+			return nil
+		}
+	default:
+	}
+	return fmt.Errorf("unsupported operator '%s' for types %v and %v", node.Token.Type, left, right)
+}
+
+func (c *Compiler) compileBitwiseExpression(node *ast.InfixExpression) error {
+	left := c.getType(node.Left)
+	if err := c.compile(node.Left); err != nil {
+		return err
+	}
+	if err := c.cast(left, interpreter.INT32); err != nil {
+		return err
+	}
+
+	right := c.getType(node.Right)
+	if err := c.compile(node.Right); err != nil {
+		return err
+	}
+	if err := c.cast(right, interpreter.INT32); err != nil {
+		return err
+	}
+
+	c.emit(bitwises[node.Token.Type])
+	return nil
+}
+
+func (c *Compiler) compileComparisonExpression(node *ast.InfixExpression) error {
+	left := c.getType(node.Left)
+	if err := c.compile(node.Left); err != nil {
+		return err
+	}
+	return c.compileComparison(node.Token.Type, left, node.Right)
+}
+
+// compileComparison compares the value of the given type on top of the stack with the node.
+func (c *Compiler) compileComparison(op token.Type, left interpreter.Type, node ast.Expression) error {
+	right := c.getType(node)
+
+	var typ interpreter.Type
+	var result bool
+	switch op {
+	case token.EQUAL, token.NOT_EQUAL:
+		typ, result = c.getEqualityType(left, right, false)
+	case token.IDENTITY_EQUAL, token.IDENTITY_NOT_EQUAL:
+		typ, result = c.getEqualityType(left, right, true)
+	default:
+		typ = c.getRelationalType(left, right)
+	}
+
+	if typ != interpreter.VOID {
+		if err := c.cast(left, typ); err != nil {
+			return err
+		}
+	}
+
+	if err := c.compile(node); err != nil {
+		return err
+	}
+	if typ != interpreter.VOID {
+		if err := c.cast(right, typ); err != nil {
+			return err
+		}
+	}
+
+	if typ == interpreter.VOID {
+		if op == token.NOT_EQUAL || op == token.IDENTITY_NOT_EQUAL {
+			result = !result
+		}
+		value := uint64(0)
+		if result {
+			value = 1
+		}
+		c.emit(bytecode.POP)
+		c.emit(bytecode.POP)
+		c.emit(bytecode.BOOLLOAD, value)
+		return nil
+	}
+
+	c.emit(comparisons[typ][op])
+	return nil
+}
+
+func (c *Compiler) compileLogicalExpression(node *ast.LogicalExpression) error {
+	left := c.getType(node.Left)
+
+	if err := c.compile(node.Left); err != nil {
+		return err
+	}
+	c.emit(bytecode.DUP)
+
+	var jump int
+	switch node.Token.Type {
+	case token.AND:
+		if err := c.cast(left, interpreter.BOOL); err != nil {
+			return err
+		}
+		jump = c.emit(bytecode.JMPF, 0)
+	case token.OR:
+		if err := c.cast(left, interpreter.BOOL); err != nil {
+			return err
+		}
+		jump = c.emit(bytecode.JMPT, 0)
+	case token.NULLISH:
+		c.emit(bytecode.ANYNULLISH)
+		jump = c.emit(bytecode.JMPF, 0)
+	default:
+		return fmt.Errorf("unsupported operator '%s' for types %v", node.Token.Type, left)
+	}
+
+	types := c.symbolTable.Types()
+	c.emit(bytecode.POP)
+	if err := c.compile(node.Right); err != nil {
+		return err
+	}
+	c.symbolTable.Merge(types)
+
+	c.patch(jump, uint64(c.offset()))
+	return nil
+}
+
+func (c *Compiler) compileConditionalExpression(node *ast.ConditionalExpression) error {
+	test := c.getType(node.Test)
+	if err := c.compile(node.Test); err != nil {
+		return err
+	}
+	if err := c.cast(test, interpreter.BOOL); err != nil {
+		return err
+	}
+	alternative := c.emit(bytecode.JMPF, 0)
+
+	left := c.getType(node.Consequent)
+	right := c.getType(node.Alternative)
+	typ := c.unify(left, right)
+
+	types := c.symbolTable.Types()
+	if err := c.compile(node.Consequent); err != nil {
+		return err
+	}
+	if err := c.cast(left, typ); err != nil {
+		return err
+	}
+	end := c.emit(bytecode.JMP, 0)
+	c.patch(alternative, uint64(c.offset()))
+
+	consequence := c.symbolTable.Types()
+	c.symbolTable.Restore(types)
+	if err := c.compile(node.Alternative); err != nil {
+		return err
+	}
+	if err := c.cast(right, typ); err != nil {
+		return err
+	}
+	c.symbolTable.Merge(consequence)
+
+	c.patch(end, uint64(c.offset()))
+	return nil
+}
+
+func (c *Compiler) compileSequenceExpression(node *ast.SequenceExpression) error {
+	for i, exp := range node.Expressions {
+		if err := c.compile(exp); err != nil {
+			return err
+		}
+		if i < len(node.Expressions)-1 {
+			c.emit(bytecode.POP)
+		}
+	}
+	return nil
+}
+
+func (c *Compiler) compileAssignmentExpression(node *ast.AssignmentExpression) error {
+	if left, ok := node.Left.(*ast.MemberExpression); ok && !left.Optional {
+		return c.compileMemberAssignment(node, left)
+	}
+	if _, ok := logicals[node.Token.Type]; ok {
+		return c.compile(c.logical(node))
+	}
+	left, ok := node.Left.(*ast.IdentifierLiteral)
+	if !ok {
+		return fmt.Errorf("invalid assignment target '%s'", node.Left.String())
+	}
+	if sym, ok := c.symbolTable.Resolve(left.Value); ok && sym.Kind == CONST {
+		return fmt.Errorf("assignment to constant variable '%s'", sym.Name)
+	}
+	if _, ok := compounds[node.Token.Type]; !ok && node.Token.Type != token.ASSIGN {
+		return fmt.Errorf("unsupported operator '%s'", node.Token.Type)
+	}
+
+	right := c.assignment(node)
+	typ := c.getType(right)
+	if err := c.compileValue(right, left.Value); err != nil {
+		return err
+	}
+
+	sym, t, ok := c.symbolTable.Lookup(left.Value)
+	if !ok {
+		sym, t = c.symbolTable.Global(left.Value), c.symbolTable.Root()
+	}
+	if sym.Kind != GLOBAL || c.frames[len(c.frames)-1].strict {
+		c.check(sym, t)
+	}
+	sym.Type = typ
+
+	if err := c.write(sym, t); err != nil {
+		return err
+	}
+	return c.read(sym, t)
+}
+
+// compileMemberAssignment evaluates the object and the key only once.
+func (c *Compiler) compileMemberAssignment(node *ast.AssignmentExpression, left *ast.MemberExpression) error {
+	if err := c.compile(left.Object); err != nil {
+		return err
+	}
+	if err := c.compileProperty(left); err != nil {
+		return err
+	}
+
+	switch node.Token.Type {
+	case token.ASSIGN:
+		if err := c.compile(node.Right); err != nil {
+			return err
+		}
+	case token.AND_ASSIGN, token.OR_ASSIGN, token.NULLISH_ASSIGN:
+		c.emit(bytecode.DUP2)
+		c.get(left)
+		c.emit(bytecode.DUP)
+		var keep int
+		switch node.Token.Type {
+		case token.AND_ASSIGN:
+			c.emit(bytecode.ANYTOBOOL)
+			keep = c.emit(bytecode.JMPF, 0)
+		case token.OR_ASSIGN:
+			c.emit(bytecode.ANYTOBOOL)
+			keep = c.emit(bytecode.JMPT, 0)
+		default:
+			c.emit(bytecode.ANYNULLISH)
+			keep = c.emit(bytecode.JMPF, 0)
+		}
+
+		types := c.symbolTable.Types()
+		c.emit(bytecode.POP)
+		if err := c.compile(node.Right); err != nil {
+			return err
+		}
+		c.set(left)
+		c.symbolTable.Merge(types)
+		end := c.emit(bytecode.JMP, 0)
+
+		c.patch(keep, uint64(c.offset()))
+		c.emit(bytecode.INSERT, 2)
+		c.emit(bytecode.POP)
+		c.emit(bytecode.POP)
+		c.emit(bytecode.POP)
+		c.patch(end, uint64(c.offset()))
+		return nil
+	default:
+		op, ok := compounds[node.Token.Type]
+		if !ok {
+			return fmt.Errorf("unsupported operator '%s'", node.Token.Type)
+		}
+		c.emit(bytecode.DUP2)
+		c.get(left)
+		if err := c.compile(ast.NewInfixExpression(token.New(op, string(op)), &stacked{}, node.Right)); err != nil {
+			return err
+		}
+	}
+	c.set(left)
+	return nil
+}
+
+func (c *Compiler) compileMemberExpression(node *ast.MemberExpression) error {
+	if err := c.compile(node.Object); err != nil {
+		return err
+	}
+	if node.Optional {
+		if err := c.optional(); err != nil {
+			return err
+		}
+	}
+	if err := c.compileProperty(node); err != nil {
+		return err
+	}
+	c.get(node)
+	return nil
+}
+
+func (c *Compiler) compileProperty(node *ast.MemberExpression) error {
+	if node.Computed {
+		return c.compile(node.Property)
+	}
+	if private, ok := node.Property.(*ast.PrivateIdentifier); ok {
+		return c.compilePrivateIdentifier(private)
+	}
+	offset, size := c.store([]byte(node.Property.String()))
+	c.emit(bytecode.STRLOAD, offset, size)
+	return nil
+}
+
+func (c *Compiler) compilePrivateIdentifier(node *ast.PrivateIdentifier) error {
+	sym, t, ok := c.symbolTable.Lookup(node.Value)
+	if !ok {
+		return fmt.Errorf("private field '%s' must be declared in an enclosing class", node.Value)
+	}
+	return c.read(sym, t)
+}
+
+// get reads the property of the member expression, looking super up from the home object.
+func (c *Compiler) get(node *ast.MemberExpression) {
+	if _, ok := node.Object.(*ast.SuperExpression); ok {
+		c.emit(bytecode.SUPERGET)
+		return
+	}
+	c.emit(bytecode.ANYGET)
+}
+
+func (c *Compiler) set(node *ast.MemberExpression) {
+	if _, ok := node.Object.(*ast.SuperExpression); ok {
+		c.emit(bytecode.SUPERSET)
+		return
+	}
+	c.emit(bytecode.ANYSET)
+}
+
+// compileCallExpression pushes the receiver of the call below the callee.
+func (c *Compiler) compileCallExpression(node *ast.CallExpression) error {
+	if _, ok := node.Callee.(*ast.SuperExpression); ok {
+		return c.compileSuperCall(node)
+	}
+	if callee, ok := node.Callee.(*ast.MemberExpression); ok {
+		if err := c.compile(callee.Object); err != nil {
+			return err
+		}
+		if callee.Optional {
+			if err := c.optional(); err != nil {
+				return err
+			}
+		}
+		c.emit(bytecode.DUP)
+		if err := c.compileProperty(callee); err != nil {
+			return err
+		}
+		c.get(callee)
+	} else {
+		c.emit(bytecode.UNDEFLOAD)
+		if err := c.compile(node.Callee); err != nil {
+			return err
+		}
+	}
+
+	if node.Optional {
+		if len(c.chains) == 0 {
+			return fmt.Errorf("optional chain outside of chain expression")
+		}
+		c.emit(bytecode.DUP)
+		c.emit(bytecode.ANYNULLISH)
+		skip := c.emit(bytecode.JMPF, 0)
+		c.emit(bytecode.POP)
+		c.chains[len(c.chains)-1] = append(c.chains[len(c.chains)-1], c.branch(bytecode.JMP))
+		c.patch(skip, uint64(c.offset()))
+	}
+	return c.arguments(node.Token, node.Arguments, bytecode.CALL, bytecode.APPLY)
+}
+
+// compileSuperCall constructs this with the parent constructor and initializes the fields on it.
+func (c *Compiler) compileSuperCall(node *ast.CallExpression) error {
+	m := c.frames[len(c.frames)-1].method
+	if m == nil || !m.derived {
+		return fmt.Errorf("'super' keyword unexpected here")
+	}
+	if err := c.arguments(node.Token, node.Arguments, bytecode.SUPERCALL, bytecode.SUPERAPPLY); err != nil {
+		return err
+	}
+	c.emit(bytecode.THISINIT)
+	if sym, ok := c.symbolTable.Frame().symbols["this"]; ok {
+		c.emit(bytecode.DUP)
+		if err := c.write(sym, c.symbolTable.Frame()); err != nil {
+			return err
+		}
+	}
+	if m.fields {
+		c.emit(bytecode.THISFIELDS)
+		c.emit(bytecode.POP)
+	}
+	return nil
+}
+
+func (c *Compiler) compileNewExpression(node *ast.NewExpression) error {
+	if err := c.compile(node.Callee); err != nil {
+		return err
+	}
+	return c.arguments(node.Token, node.Arguments, bytecode.NEW, bytecode.NEWAPPLY)
+}
+
+// arguments pushes the arguments and invokes the callee, collecting spread ones into an array.
+func (c *Compiler) arguments(tok token.Token, arguments []ast.Expression, op, apply bytecode.Opcode) error {
+	if len(arguments) > math.MaxUint8 {
+		return fmt.Errorf("too many arguments in call expression")
+	}
+
+	spread := false
+	for _, arg := range arguments {
+		if _, ok := arg.(*ast.SpreadElement); ok {
+			spread = true
+		}
+	}
+	if !spread {
+		for _, arg := range arguments {
+			if err := c.compile(arg); err != nil {
+				return err
+			}
+		}
+		c.emit(op, uint64(len(arguments)))
+		return nil
+	}
+
+	if err := c.compileArrayLiteral(ast.NewArrayLiteral(tok, arguments...)); err != nil {
+		return err
+	}
+	c.emit(apply)
+	return nil
+}
+
+// compileChainExpression evaluates to undefined when an optional link meets a nullish value.
+func (c *Compiler) compileChainExpression(node *ast.ChainExpression) error {
+	c.chains = append(c.chains, nil)
+	err := c.compile(node.Expression)
+	branches := c.chains[len(c.chains)-1]
+	c.chains = c.chains[:len(c.chains)-1]
+	if err != nil {
+		return err
+	}
+
+	end := c.emit(bytecode.JMP, 0)
+
+	types := c.symbolTable.Types()
+	c.symbolTable.Restore(branches[0].types)
+	for _, b := range branches {
+		c.symbolTable.Merge(b.types)
+		c.patch(b.index, uint64(c.offset()))
+	}
+	c.emit(bytecode.POP)
+	c.emit(bytecode.UNDEFLOAD)
+	c.symbolTable.Merge(types)
+
+	c.patch(end, uint64(c.offset()))
+	return nil
+}
+
+func (c *Compiler) compileFunctionExpression(node *ast.FunctionExpression) error {
+	name := ""
+	if node.Name != nil {
+		name = node.Name.Value
+	}
+	return c.compileFunction(node, name, name, interpreter.CONSTRUCTOR, nil)
+}
+
+func (c *Compiler) compileArrowFunctionExpression(node *ast.ArrowFunctionExpression) error {
+	return c.compileFunction(node, "", "", 0, nil)
+}
+
+func (c *Compiler) compileThisExpression(_ *ast.ThisExpression) error {
+	sym, t, ok := c.symbolTable.Lookup("this")
+	if !ok {
+		c.emit(bytecode.THISLOAD)
+		return nil
+	}
+	c.check(sym, t)
+	return c.read(sym, t)
+}
+
+func (c *Compiler) compileSuperExpression(_ *ast.SuperExpression) error {
+	if c.frames[len(c.frames)-1].method == nil {
+		return fmt.Errorf("'super' keyword unexpected here")
+	}
+	return c.compileThisExpression(nil)
+}
+
+func (c *Compiler) compileClassExpression(node *ast.ClassExpression) error {
+	name := ""
+	if node.Name != nil {
+		name = node.Name.Value
+	}
+	return c.compileClass(node, name)
+}
+
+// compileClass loads the constructor of the class, whose prototype holds its methods.
+func (c *Compiler) compileClass(node *ast.ClassExpression, name string) error {
+	c.symbolTable = NewEnclosedSymbolTable(c.symbolTable)
+	f := c.frames[len(c.frames)-1]
+	restore := f.strict
+	f.strict = true
+	err := func() error {
+		var self *Symbol
+		if node.Name != nil {
+			sym, err := c.symbolTable.Declare(node.Name.Value, CONST)
+			if err != nil {
+				return err
+			}
+			sym.Type = interpreter.UNKNOWN
+			c.emit(bytecode.SLTCLEAR, uint64(sym.Index))
+			self = sym
+		}
+
+		kinds := map[string]string{}
+		var ctor *ast.FunctionExpression
+		fields := false
+		for _, member := range node.Members {
+			var key ast.Expression
+			kind := ast.METHOD
+			switch member := member.(type) {
+			case *ast.MethodDefinition:
+				if member.Kind == ast.CONSTRUCTOR {
+					ctor = member.Value
+					continue
+				}
+				key, kind = member.Key, member.Kind
+			case *ast.PropertyDefinition:
+				key = member.Key
+				fields = fields || !member.Static
+			default:
+			}
+
+			private, ok := key.(*ast.PrivateIdentifier)
+			if !ok {
+				continue
+			}
+			if prev, ok := kinds[private.Value]; ok {
+				if (prev == ast.GET && kind == ast.SET) || (prev == ast.SET && kind == ast.GET) {
+					kinds[private.Value] = ""
+					continue
+				}
+				return fmt.Errorf("identifier '%s' has already been declared", private.Value)
+			}
+			kinds[private.Value] = kind
+
+			sym := c.symbolTable.Define(private.Value)
+			sym.Kind = CONST
+			sym.Type = interpreter.UNKNOWN
+			offset, size := c.store([]byte(private.Value))
+			c.emit(bytecode.STRLOAD, offset, size)
+			c.emit(bytecode.PRIVNEW)
+			if err := c.write(sym, c.symbolTable); err != nil {
+				return err
+			}
+		}
+
+		derived := node.SuperClass != nil
+		if derived {
+			if err := c.compile(node.SuperClass); err != nil {
+				return err
+			}
+		}
+
+		flags := interpreter.CONSTRUCTOR | interpreter.CLASS
+		if derived {
+			flags |= interpreter.DERIVED
+		}
+		if ctor == nil {
+			ctor = c.constructor(derived)
+		}
+		if err := c.compileFunction(ctor, name, "", flags, &method{derived: derived, fields: fields}); err != nil {
+			return err
+		}
+		if derived {
+			c.emit(bytecode.FNEXTEND)
+		}
+
+		c.emit(bytecode.DUP)
+		offset, size := c.store([]byte("prototype"))
+		c.emit(bytecode.STRLOAD, offset, size)
+		c.emit(bytecode.ANYGET)
+
+		var instances, statics []ast.Statement
+		for i, member := range node.Members {
+			switch member := member.(type) {
+			case *ast.MethodDefinition:
+				if member.Kind == ast.CONSTRUCTOR {
+					continue
+				}
+				if member.Static {
+					c.emit(bytecode.DUP2)
+					c.emit(bytecode.POP)
+				} else {
+					c.emit(bytecode.DUP)
+				}
+				key, err := c.compileKey(member.Key, member.Computed)
+				if err != nil {
+					return err
+				}
+				if key != "" && (member.Kind == ast.GET || member.Kind == ast.SET) {
+					key = member.Kind + " " + key
+				}
+				if err := c.compileFunction(member.Value, key, "", 0, &method{}); err != nil {
+					return err
+				}
+				switch member.Kind {
+				case ast.GET:
+					c.emit(bytecode.OBJGETTER, uint64(interpreter.CONFIGURABLE))
+				case ast.SET:
+					c.emit(bytecode.OBJSETTER, uint64(interpreter.CONFIGURABLE))
+				default:
+					c.emit(bytecode.OBJMETHOD, uint64(interpreter.WRITABLE|interpreter.CONFIGURABLE))
+				}
+				c.emit(bytecode.POP)
+			case *ast.PropertyDefinition:
+				field := member
+				if member.Computed {
+					hidden := fmt.Sprintf("%%key%d", i)
+					c.symbolTable.Capture(hidden)
+					sym := c.symbolTable.Define(hidden)
+					sym.Type = interpreter.UNKNOWN
+					if err := c.compile(member.Key); err != nil {
+						return err
+					}
+					if err := c.write(sym, c.symbolTable); err != nil {
+						return err
+					}
+					key := ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, hidden), hidden)
+					field = ast.NewPropertyDefinition(key, member.Value, true, member.Static)
+				}
+				if member.Static {
+					statics = append(statics, ast.NewExpressionStatement(field))
+				} else {
+					instances = append(instances, ast.NewExpressionStatement(field))
+				}
+			default:
+			}
+		}
+
+		if len(instances) > 0 {
+			initializer := ast.NewFunctionExpression(node.Token, nil, nil, ast.NewBlockStatement(instances...))
+			if err := c.compileFunction(initializer, "", "", 0, &method{}); err != nil {
+				return err
+			}
+			c.emit(bytecode.FNHOME)
+			c.emit(bytecode.FNFIELDS)
+		} else {
+			c.emit(bytecode.POP)
+		}
+
+		if self != nil {
+			c.emit(bytecode.DUP)
+			if err := c.write(self, c.symbolTable); err != nil {
+				return err
+			}
+			self.Initialized = true
+		}
+
+		if len(statics) > 0 {
+			c.emit(bytecode.DUP)
+			c.emit(bytecode.DUP)
+			initializer := ast.NewFunctionExpression(node.Token, nil, nil, ast.NewBlockStatement(statics...))
+			if err := c.compileFunction(initializer, "", "", 0, &method{}); err != nil {
+				return err
+			}
+			c.emit(bytecode.FNHOME)
+			c.emit(bytecode.CALL, 0)
+			c.emit(bytecode.POP)
+		}
+		return nil
+	}()
+	f.strict = restore
+	c.symbolTable.Free()
+	c.symbolTable = c.symbolTable.Outer()
+	return err
+}
+
+// constructor returns the default constructor of a class declaring none.
+func (c *Compiler) constructor(derived bool) *ast.FunctionExpression {
+	fn := token.New(token.FUNCTION, string(token.FUNCTION))
+	if !derived {
+		return ast.NewFunctionExpression(fn, nil, nil, ast.NewBlockStatement())
+	}
+	args := ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "args"), "args")
+	ellipsis := token.New(token.ELLIPSIS, string(token.ELLIPSIS))
+	call := ast.NewCallExpression(
+		token.New(token.OPEN_PAREN, string(token.OPEN_PAREN)),
+		ast.NewSuperExpression(token.New(token.SUPER, string(token.SUPER))),
+		[]ast.Expression{ast.NewSpreadElement(ellipsis, args)},
+		false,
+	)
+	return ast.NewFunctionExpression(fn, nil, []ast.Expression{ast.NewRestElement(ellipsis, args)}, ast.NewBlockStatement(ast.NewExpressionStatement(call)))
+}
+
+// compileKey pushes the key of a class member, returning its name unless computed.
+func (c *Compiler) compileKey(key ast.Expression, computed bool) (string, error) {
+	if computed {
+		return "", c.compile(key)
+	}
+	if private, ok := key.(*ast.PrivateIdentifier); ok {
+		return private.Value, c.compilePrivateIdentifier(private)
+	}
+	name := c.key(key)
+	offset, size := c.store([]byte(name))
+	c.emit(bytecode.STRLOAD, offset, size)
+	return name, nil
+}
+
+func (c *Compiler) compilePropertyDefinition(node *ast.PropertyDefinition) error {
+	c.emit(bytecode.THISLOAD)
+	name, err := c.compileKey(node.Key, node.Computed)
+	if err != nil {
+		return err
+	}
+	if node.Value == nil {
+		c.emit(bytecode.UNDEFLOAD)
+	} else if err := c.compileValue(node.Value, name); err != nil {
+		return err
+	}
+	c.emit(bytecode.OBJPUT)
+	return nil
+}
+
+// compileFunction emits the body of the function, skipped over, and loads the function with the cells it captures.
+func (c *Compiler) compileFunction(node ast.Expression, name, self string, flags byte, m *method) error {
+	var parameters []ast.Expression
+	var body *ast.BlockStatement
+	arrow := false
+	switch node := node.(type) {
+	case *ast.FunctionExpression:
+		parameters, body = node.Parameters, node.Body
+	case *ast.ArrowFunctionExpression:
+		parameters, arrow = node.Parameters, true
+		if block, ok := node.Body.(*ast.BlockStatement); ok {
+			body = block
+		} else {
+			body = ast.NewBlockStatement(ast.NewReturnStatement(token.New(token.RETURN, string(token.RETURN)), node.Body.(ast.Expression)))
+		}
+	default:
+		return fmt.Errorf("unsupported function type: %T", node)
+	}
+
+	if len(parameters) > math.MaxUint8 {
+		return fmt.Errorf("too many parameters in function")
+	}
+
+	jump := c.emit(bytecode.JMP, 0)
+	entry := c.offset()
+
+	outer := c.symbolTable
+	types := outer.Types()
+	loops, labels, chains, guards := c.loops, c.labels, c.chains, c.guards
+
+	for _, g := range guards {
+		g.suspend(len(c.instructions))
+	}
+
+	if arrow && c.frames[len(c.frames)-1].method != nil {
+		m = &method{}
+	}
+
+	c.symbolTable = NewFrameSymbolTable(outer)
+	c.frames = append(c.frames, &frame{symbolTable: c.symbolTable, method: m, strict: c.frames[len(c.frames)-1].strict || strict(body.Statements)})
+	c.loops, c.labels, c.chains, c.guards = nil, nil, nil, nil
+	if c.frames[len(c.frames)-1].strict {
+		c.emit(bytecode.STRICT)
+	}
+
+	arity, length := 0, len(parameters)
+	err := func() error {
+		nodes := []ast.Node{body}
+		for _, param := range parameters {
+			nodes = append(nodes, param)
+		}
+
+		c.symbolTable.Capture(c.captures(nodes...)...)
+		c.symbolTable.Define(self)
+		for i, param := range parameters {
+			switch param := param.(type) {
+			case *ast.IdentifierLiteral:
+				c.symbolTable.Define(param.Value)
+				arity++
+				continue
+			case *ast.AssignmentExpression:
+				ident, ok := param.Left.(*ast.IdentifierLiteral)
+				if !ok {
+					return fmt.Errorf("invalid parameter '%s'", param.String())
+				}
+				c.symbolTable.Define(ident.Value)
+				arity++
+			case *ast.RestElement:
+				c.symbolTable.Define(param.Argument.Value)
+			default:
+				return fmt.Errorf("invalid parameter '%s'", param.String())
+			}
+			length = min(length, i)
+		}
+
+		if !arrow {
+			if err := c.bind("arguments", bytecode.ARGSLOAD, nodes...); err != nil {
+				return err
+			}
+		}
+		switch {
+		case arrow:
+		case m != nil && m.derived:
+			if c.lexical("this", nodes...) {
+				sym := c.symbolTable.Define("this")
+				sym.Initialized = false
+				c.emit(bytecode.SLTCLEAR, uint64(sym.Index))
+			}
+		default:
+			if err := c.bind("this", bytecode.THISLOAD, nodes...); err != nil {
+				return err
+			}
+			if m != nil && m.fields {
+				c.emit(bytecode.THISFIELDS)
+				c.emit(bytecode.POP)
+			}
+		}
+		if err := c.parameters(parameters); err != nil {
+			return err
+		}
+
+		if err := c.hoist(body.Statements); err != nil {
+			return err
+		}
+		for _, n := range body.Statements {
+			if err := c.compile(n); err != nil {
+				return err
+			}
+		}
+		c.emit(bytecode.UNDEFLOAD)
+		c.emit(bytecode.RETURN)
+		if c.symbolTable.Size() > math.MaxUint16 {
+			return fmt.Errorf("too many variables in function")
+		}
+		return nil
+	}()
+
+	f := c.frames[len(c.frames)-1]
+	c.symbolTable = outer
+	c.frames = c.frames[:len(c.frames)-1]
+	c.loops, c.labels, c.chains, c.guards = loops, labels, chains, guards
+	for _, g := range guards {
+		g.resume(len(c.instructions))
+	}
+	if err != nil {
+		return err
+	}
+
+	outer.Restore(types)
+	for sym := range outer.Types() {
+		if sym.Captured {
+			sym.Type = interpreter.UNKNOWN
+		}
+	}
+
+	c.patch(jump, uint64(c.offset()))
+	offset, size := c.store([]byte(name))
+	c.emit(bytecode.FNLOAD, uint64(entry), uint64(arity), uint64(length), offset, size, uint64(flags))
+	if len(f.upvalues) > math.MaxUint16 {
+		return fmt.Errorf("too many captured variables in function")
+	}
+	for _, u := range f.upvalues {
+		kind := uint64(1)
+		if u.local {
+			kind = 0
+		}
+		c.emit(bytecode.FNCAPTURE, kind, uint64(u.index))
+	}
+	return nil
+}
+
+// bind defines the implicit binding named after the name unless the nodes never refer to it.
+func (c *Compiler) bind(name string, op bytecode.Opcode, nodes ...ast.Node) error {
+	if _, ok := c.symbolTable.symbols[name]; ok || !c.lexical(name, nodes...) {
+		return nil
+	}
+	c.emit(op)
+	return c.write(c.symbolTable.Define(name), c.symbolTable)
+}
+
+// parameters initializes the parameters with defaults and the rest parameter.
+func (c *Compiler) parameters(parameters []ast.Expression) error {
+	pending := c.pending(parameters)
+	for i := len(pending) - 1; i >= 0; i-- {
+		if _, ok := parameters[i].(*ast.RestElement); ok || pending[i] == nil {
+			continue
+		}
+		if err := c.read(pending[i], c.symbolTable); err != nil {
+			return err
+		}
+	}
+	for _, sym := range pending {
+		if sym != nil {
+			c.emit(bytecode.SLTCLEAR, uint64(sym.Index))
+			sym.Initialized = false
+		}
+	}
+
+	for _, param := range parameters {
+		switch param := param.(type) {
+		case *ast.IdentifierLiteral:
+			sym := c.symbolTable.symbols[param.Value]
+			if sym.Initialized {
+				continue
+			}
+			if err := c.write(sym, c.symbolTable); err != nil {
+				return err
+			}
+			sym.Initialized = true
+		case *ast.AssignmentExpression:
+			name := param.Left.(*ast.IdentifierLiteral).Value
+			sym := c.symbolTable.symbols[name]
+			if sym.Initialized {
+				if err := c.read(sym, c.symbolTable); err != nil {
+					return err
+				}
+			} else {
+				c.emit(bytecode.DUP)
+			}
+			c.emit(bytecode.UNDEFLOAD)
+			c.emit(bytecode.ANYSTRICTEQ)
+			skip := c.emit(bytecode.JMPF, 0)
+
+			types := c.symbolTable.Types()
+			if !sym.Initialized {
+				c.emit(bytecode.POP)
+			}
+			if err := c.compileValue(param.Right, name); err != nil {
+				return err
+			}
+			if sym.Initialized {
+				if err := c.write(sym, c.symbolTable); err != nil {
+					return err
+				}
+			}
+			c.symbolTable.Merge(types)
+			c.patch(skip, uint64(c.offset()))
+			if !sym.Initialized {
+				if err := c.write(sym, c.symbolTable); err != nil {
+					return err
+				}
+				sym.Initialized = true
+			}
+		case *ast.RestElement:
+			sym := c.symbolTable.symbols[param.Argument.Value]
+			c.emit(bytecode.RESTLOAD)
+			if err := c.write(sym, c.symbolTable); err != nil {
+				return err
+			}
+			sym.Initialized = true
+		default:
+		}
+	}
+	return nil
+}
+
+// pending returns the symbols of the parameters a default may refer to before they are initialized, indexed like the parameters.
+func (c *Compiler) pending(parameters []ast.Expression) []*Symbol {
+	var pending []*Symbol
+	var defaults []ast.Node
+	for i, param := range parameters {
+		var name string
+		switch param := param.(type) {
+		case *ast.IdentifierLiteral:
+			name = param.Value
+		case *ast.AssignmentExpression:
+			name = param.Left.(*ast.IdentifierLiteral).Value
+			defaults = append(defaults, param.Right)
+		case *ast.RestElement:
+			name = param.Argument.Value
+		default:
+		}
+
+		found := false
+		for _, node := range defaults {
+			ast.Inspect(node, func(n ast.Node) bool {
+				if ident, ok := n.(*ast.IdentifierLiteral); ok && ident.Value == name {
+					found = true
+				}
+				return !found
+			})
+		}
+		if found {
+			if pending == nil {
+				pending = make([]*Symbol, len(parameters))
+			}
+			pending[i] = c.symbolTable.symbols[name]
+		}
+	}
+	return pending
 }
 
-func (c *Compiler) compileBlockStatement(node *ast.BlockStatement) error {
-	for _, n := range node.Statements {
-		if err := c.compile(n); err != nil {
-			return err
+// compileValue compiles the value bound to the name, naming anonymous functions and classes.
+func (c *Compiler) compileValue(node ast.Expression, name string) error {
+	switch fn := node.(type) {
+	case *ast.FunctionExpression:
+		if fn.Name == nil {
+			return c.compileFunction(fn, name, "", interpreter.CONSTRUCTOR, nil)
 		}
+	case *ast.ArrowFunctionExpression:
+		return c.compileFunction(fn, name, "", 0, nil)
+	case *ast.ClassExpression:
+		if fn.Name == nil {
+			return c.compileClass(fn, name)
+		}
+	default:
 	}
-	return nil
+	return c.compile(node)
 }
 
-func (c *Compiler) compileExpressionStatement(node *ast.ExpressionStatement) error {
-	if err := c.compile(node.Expression); err != nil {
-		return err
+// optional short-circuits the enclosing chain when the value on the stack is nullish.
+func (c *Compiler) optional() error {
+	if len(c.chains) == 0 {
+		return fmt.Errorf("optional chain outside of chain expression")
 	}
-	c.emit(bytecode.POP)
+	c.emit(bytecode.DUP)
+	c.emit(bytecode.ANYNULLISH)
+	c.chains[len(c.chains)-1] = append(c.chains[len(c.chains)-1], c.branch(bytecode.JMPT))
 	return nil
 }
 
-func (c *Compiler) compileVariableStatement(node *ast.VariableStatement) error {
-	switch node.Token.Type {
-	case token.VAR:
-		for _, n := range node.Right {
-			if err := c.compile(n); err != nil {
+func (c *Compiler) compileArrayLiteral(node *ast.ArrayLiteral) error {
+	c.emit(bytecode.ARRNEW)
+	for _, elem := range node.Elements {
+		switch elem := elem.(type) {
+		case nil:
+			c.emit(bytecode.ARRHOLE)
+		case *ast.SpreadElement:
+			if err := c.compile(elem.Argument); err != nil {
 				return err
 			}
-			c.emit(bytecode.POP)
+			c.emit(bytecode.ARRSPREAD)
+		default:
+			if err := c.compile(elem); err != nil {
+				return err
+			}
+			c.emit(bytecode.ARRPUSH)
 		}
-		return nil
-	default:
-		return fmt.Errorf("invalid variable token type: %s", node.Token.Type)
 	}
+	return nil
 }
 
-func (c *Compiler) compilePrefixExpression(node *ast.PrefixExpression) error {
-	typ := c.getType(node)
-	right := c.getType(node.Right)
-
-	if err := c.compile(node.Right); err != nil {
-		return err
-	}
-	if err := c.cast(right, typ); err != nil {
-		return err
-	}
-
-	switch node.Token.Type {
-	case token.PLUS, token.MINUS:
-		if node.Token.Type == token.MINUS {
-			switch typ {
-			case interpreter.INT32:
-				c.emit(bytecode.I32LOAD, uint64(0xFFFFFFFFFFFFFFFF))
-				c.emit(bytecode.I32MUL)
-			case interpreter.FLOAT64:
-				c.emit(bytecode.F64LOAD, math.Float64bits(-1))
-				c.emit(bytecode.F64MUL)
-			default:
+// compileObjectLiteral puts the properties onto a new object, where a __proto__ property sets its prototype.
+func (c *Compiler) compileObjectLiteral(node *ast.ObjectLiteral) error {
+	c.emit(bytecode.OBJNEW)
+	for _, prop := range node.Properties {
+		var name string
+		proto := false
+		if prop.Computed {
+			if err := c.compile(prop.Key); err != nil {
+				return err
+			}
+		} else {
+			name = c.key(prop.Key)
+			proto = name == "__proto__" && !prop.Shorthand && !prop.Method
+			if proto {
+				c.emit(bytecode.DUP)
 			}
+			offset, size := c.store([]byte(name))
+			c.emit(bytecode.STRLOAD, offset, size)
 		}
-		return nil
-	}
-	return fmt.Errorf("unsupported operator '%s' for types %v", node.Token.Type, right)
-}
-
-func (c *Compiler) compileInfixExpression(node *ast.InfixExpression) error {
-	typ := c.getType(node)
-	left := c.getType(node.Left)
-	right := c.getType(node.Right)
-
-	if err := c.compile(node.Left); err != nil {
-		return err
-	}
-	if err := c.cast(left, typ); err != nil {
-		return err
-	}
 
-	if err := c.compile(node.Right); err != nil {
-		return err
-	}
-	if err := c.cast(right, typ); err != nil {
-		return err
-	}
+		if !prop.Method {
+			if err := c.compileValue(prop.Value, name); err != nil {
+				return err
+			}
+			if proto {
+				c.emit(bytecode.ANYSET)
+				c.emit(bytecode.POP)
+			} else {
+				c.emit(bytecode.OBJPUT)
+			}
+			continue
+		}
 
-	switch typ {
-	case interpreter.INT32:
-		switch node.Token.Type {
-		case token.PLUS:
-			c.emit(bytecode.I32ADD)
-			return nil
-		case token.MINUS:
-			c.emit(bytecode.I32SUB)
-			return nil
-		case token.MULTIPLY:
-			c.emit(bytecode.I32MUL)
-			return nil
+		if name != "" && (prop.Kind == ast.GET || prop.Kind == ast.SET) {
+			name = prop.Kind + " " + name
 		}
-	case interpreter.FLOAT64:
-		switch node.Token.Type {
-		case token.PLUS:
-			c.emit(bytecode.F64ADD)
-			return nil
-		case token.MINUS:
-			c.emit(bytecode.F64SUB)
-			return nil
-		case token.MULTIPLY:
-			c.emit(bytecode.F64MUL)
-			return nil
-		case token.DIVIDE:
-			c.emit(bytecode.F64DIV)
-			return nil
-		case token.MODULUS:
-			c.emit(bytecode.F64MOD)
-			return nil
+		if err := c.compileFunction(prop.Value, name, "", 0, &method{}); err != nil {
+			return err
 		}
-	case interpreter.STRING:
-		switch node.Token.Type {
-		case token.PLUS:
-			c.emit(bytecode.STRADD)
-			return nil
+		switch prop.Kind {
+		case ast.GET:
+			c.emit(bytecode.OBJGETTER, uint64(interpreter.ENUMERABLE|interpreter.CONFIGURABLE))
+		case ast.SET:
+			c.emit(bytecode.OBJSETTER, uint64(interpreter.ENUMERABLE|interpreter.CONFIGURABLE))
+		default:
+			c.emit(bytecode.OBJMETHOD, uint64(interpreter.WRITABLE|interpreter.ENUMERABLE|interpreter.CONFIGURABLE))
 		}
-	default:
 	}
-	return fmt.Errorf("unsupported operator '%s' for types %v and %v", node.Token.Type, left, right)
+	return nil
 }
 
-func (c *Compiler) compileAssignmentExpression(node *ast.AssignmentExpression) error {
-	if err := c.compile(node.Right); err != nil {
-		return err
+// strict reports whether the statements begin with a directive prologue containing "use strict".
+func strict(statements []ast.Statement) bool {
+	for _, n := range statements {
+		stmt, ok := n.(*ast.ExpressionStatement)
+		if !ok {
+			return false
+		}
+		lit, ok := stmt.Expression.(*ast.StringLiteral)
+		if !ok {
+			return false
+		}
+		if lit.Value == "use strict" {
+			return true
+		}
 	}
+	return false
+}
 
-	sym, ok := c.symbolTable.Resolve(node.Left.String())
-	if !ok {
-		sym = c.symbolTable.Define(node.Left.String())
+func (c *Compiler) key(node ast.Expression) string {
+	switch node := node.(type) {
+	case *ast.StringLiteral:
+		return node.Value
+	case *ast.NumberLiteral:
+		return string(interpreter.ToString(interpreter.Float64(node.Value)))
+	default:
+		return node.String()
 	}
-	sym.Type = c.getType(node.Right)
-
-	c.emit(bytecode.SLTSTORE, uint64(sym.Index))
-	c.emit(bytecode.SLTLOAD, uint64(sym.Index))
-	return nil
 }
 
 func (c *Compiler) compileNullLiteral(_ *ast.NullLiteral) error {
@@ -324,20 +2880,110 @@ func (c *Compiler) compileStringLiteral(node *ast.StringLiteral) error {
 }
 
 func (c *Compiler) compileIdentifierLiteral(node *ast.IdentifierLiteral) error {
-	sym, ok := c.symbolTable.Resolve(node.Value)
+	return c.identifier(node, true)
+}
+
+// identifier pushes the value of the identifier, failing on globals never declared unless unchecked.
+func (c *Compiler) identifier(node *ast.IdentifierLiteral, checked bool) error {
+	sym, t, ok := c.symbolTable.Lookup(node.Value)
 	if !ok {
-		return fmt.Errorf("undefined identifier: %s", node.Value)
+		if _, ok := interpreter.Builtin(interpreter.String(node.Value)); ok {
+			offset, size := c.store([]byte(node.Value))
+			c.emit(bytecode.BUILTINLOAD, offset, size)
+			return nil
+		}
+		sym, t = c.symbolTable.Global(node.Value), c.symbolTable.Root()
+	}
+	if checked || sym.Kind != GLOBAL {
+		c.check(sym, t)
+	}
+	return c.read(sym, t)
+}
+
+func (c *Compiler) read(sym *Symbol, t *SymbolTable) error {
+	switch c.storage(sym, t) {
+	case slot:
+		c.emit(bytecode.SLTLOAD, uint64(sym.Index))
+	case cell:
+		c.emit(bytecode.CELLLOAD, uint64(sym.Index))
+	case global:
+		sym.Captured = true
+		c.emit(bytecode.GLBLOAD, uint64(sym.Index))
+	default:
+		if !sym.Captured {
+			return fmt.Errorf("cannot capture '%s' of an enclosing function", sym.Name)
+		}
+		c.emit(bytecode.UPVLOAD, uint64(c.upvalue(len(c.frames)-1, sym, t)))
+	}
+	return nil
+}
+
+func (c *Compiler) write(sym *Symbol, t *SymbolTable) error {
+	for _, g := range c.guards {
+		g.writes[sym] = true
+	}
+	switch c.storage(sym, t) {
+	case slot:
+		c.emit(bytecode.SLTSTORE, uint64(sym.Index))
+	case cell:
+		c.emit(bytecode.CELLSTORE, uint64(sym.Index))
+	case global:
+		sym.Captured = true
+		c.emit(bytecode.GLBSTORE, uint64(sym.Index))
+	default:
+		if !sym.Captured {
+			return fmt.Errorf("cannot capture '%s' of an enclosing function", sym.Name)
+		}
+		c.emit(bytecode.UPVSTORE, uint64(c.upvalue(len(c.frames)-1, sym, t)))
 	}
-	c.emit(bytecode.SLTLOAD, uint64(sym.Index))
 	return nil
 }
 
+func (c *Compiler) storage(sym *Symbol, t *SymbolTable) storage {
+	switch {
+	case t.Frame() == c.symbolTable.Frame():
+		if sym.Captured && t != t.Root() {
+			return cell
+		}
+		return slot
+	case t == t.Root():
+		return global
+	default:
+		return upvalue
+	}
+}
+
+// upvalue returns the index of the upvalue capturing the symbol in the frame at the depth.
+func (c *Compiler) upvalue(depth int, sym *Symbol, t *SymbolTable) int {
+	f := c.frames[depth]
+	for i, u := range f.upvalues {
+		if u.symbol == sym {
+			return i
+		}
+	}
+
+	u := capture{symbol: sym, local: true, index: sym.Index}
+	if c.frames[depth-1].symbolTable != t.Frame() {
+		u = capture{symbol: sym, index: c.upvalue(depth-1, sym, t)}
+	}
+	f.upvalues = append(f.upvalues, u)
+	return len(f.upvalues) - 1
+}
+
 func (c *Compiler) getType(node ast.Expression) interpreter.Type {
 	switch node := node.(type) {
 	case *ast.PrefixExpression:
 		return c.getPrefixExpressionType(node)
+	case *ast.UpdateExpression:
+		return c.getUpdateExpressionType(node)
 	case *ast.InfixExpression:
 		return c.getInfixExpressionType(node)
+	case *ast.LogicalExpression:
+		return c.getLogicalExpressionType(node)
+	case *ast.ConditionalExpression:
+		return c.getConditionalExpressionType(node)
+	case *ast.SequenceExpression:
+		return c.getSequenceExpressionType(node)
 	case *ast.AssignmentExpression:
 		return c.getAssignmentExpression(node)
 	case *ast.NullLiteral:
@@ -360,41 +3006,66 @@ func (c *Compiler) getType(node ast.Expression) interpreter.Type {
 func (c *Compiler) getPrefixExpressionType(node *ast.PrefixExpression) interpreter.Type {
 	right := c.getType(node.Right)
 	switch node.Token.Type {
-	case token.PLUS, token.MINUS:
+	case token.TYPEOF:
+		return interpreter.STRING
+	case token.VOID:
+		return interpreter.UNDEFINED
+	case token.DELETE:
+		return interpreter.BOOL
+	case token.NOT:
+		return interpreter.BOOL
+	case token.BIT_NOT:
+		return interpreter.INT32
+	case token.PLUS:
 		switch right {
-		case interpreter.BOOL:
+		case interpreter.NULL, interpreter.BOOL:
 			return interpreter.INT32
-		case interpreter.STRING:
-			return interpreter.FLOAT64
 		case interpreter.INT32, interpreter.FLOAT64:
 			return right
 		default:
-			return interpreter.UNKNOWN
+			return interpreter.FLOAT64
+		}
+	case token.MINUS:
+		if n, ok := node.Right.(*ast.NumberLiteral); ok && n.Value != 0 {
+			return right
 		}
+		return interpreter.FLOAT64
 	}
 	return interpreter.UNKNOWN
 }
 
+func (c *Compiler) getUpdateExpressionType(node *ast.UpdateExpression) interpreter.Type {
+	switch c.getType(node.Operand) {
+	case interpreter.NULL, interpreter.BOOL, interpreter.INT32:
+		return interpreter.INT32
+	default:
+		return interpreter.FLOAT64
+	}
+}
+
 func (c *Compiler) getInfixExpressionType(node *ast.InfixExpression) interpreter.Type {
 	left := c.getType(node.Left)
 	right := c.getType(node.Right)
 
-	if left == interpreter.UNKNOWN || right == interpreter.UNKNOWN {
-		return interpreter.UNKNOWN
-	}
-
 	switch node.Token.Type {
+	case token.EQUAL, token.NOT_EQUAL, token.IDENTITY_EQUAL, token.IDENTITY_NOT_EQUAL,
+		token.LESS_THAN, token.GREATER_THAN, token.LESS_THAN_OR_EQUAL, token.GREATER_THAN_OR_EQUAL, token.INSTANCEOF:
+		return interpreter.BOOL
 	case token.PLUS:
 		if left == interpreter.STRING || right == interpreter.STRING {
 			return interpreter.STRING
+		} else if left == interpreter.UNKNOWN || right == interpreter.UNKNOWN {
+			return interpreter.UNKNOWN
 		} else if left == interpreter.FLOAT64 || right == interpreter.FLOAT64 {
 			return interpreter.FLOAT64
 		} else if left == interpreter.INT32 && right == interpreter.INT32 {
 			return interpreter.INT32
 		}
 		return interpreter.FLOAT64
-	case token.DIVIDE, token.MODULUS:
+	case token.DIVIDE, token.MODULUS, token.EXPONENT, token.RIGHT_SHIFT_LOGICAL:
 		return interpreter.FLOAT64
+	case token.BIT_AND, token.BIT_OR, token.BIT_XOR, token.LEFT_SHIFT_ARITHMETIC, token.RIGHT_SHIFT_ARITHMETIC:
+		return interpreter.INT32
 	default:
 		if left == interpreter.FLOAT64 || right == interpreter.FLOAT64 {
 			return interpreter.FLOAT64
@@ -405,8 +3076,109 @@ func (c *Compiler) getInfixExpressionType(node *ast.InfixExpression) interpreter
 	}
 }
 
+func (c *Compiler) getLogicalExpressionType(node *ast.LogicalExpression) interpreter.Type {
+	left := c.getType(node.Left)
+	right := c.getType(node.Right)
+	if left != right {
+		return interpreter.UNKNOWN
+	}
+	return left
+}
+
+func (c *Compiler) getConditionalExpressionType(node *ast.ConditionalExpression) interpreter.Type {
+	return c.unify(c.getType(node.Consequent), c.getType(node.Alternative))
+}
+
+// getSequenceExpressionType returns the type of the last expression unless the preceding ones change symbols.
+func (c *Compiler) getSequenceExpressionType(node *ast.SequenceExpression) interpreter.Type {
+	last := len(node.Expressions) - 1
+	for _, exp := range node.Expressions[:last] {
+		changed := false
+		ast.Inspect(exp, func(n ast.Node) bool {
+			switch n.(type) {
+			case *ast.AssignmentExpression, *ast.UpdateExpression:
+				changed = true
+			}
+			return !changed
+		})
+		if changed {
+			return interpreter.UNKNOWN
+		}
+	}
+	return c.getType(node.Expressions[last])
+}
+
+// getEqualityType returns the type both operands are converted to before comparing them.
+// VOID means the result does not depend on the operand values and is returned as constant.
+func (c *Compiler) getEqualityType(left, right interpreter.Type, strict bool) (interpreter.Type, bool) {
+	if left == interpreter.UNKNOWN || right == interpreter.UNKNOWN {
+		return interpreter.UNKNOWN, false
+	}
+
+	nullish := func(typ interpreter.Type) bool {
+		return typ == interpreter.UNDEFINED || typ == interpreter.NULL
+	}
+	numeric := func(typ interpreter.Type) bool {
+		return typ == interpreter.INT32 || typ == interpreter.FLOAT64
+	}
+
+	if left == right {
+		switch left {
+		case interpreter.UNDEFINED, interpreter.NULL:
+			return interpreter.VOID, true
+		case interpreter.BOOL:
+			return interpreter.INT32, false
+		default:
+			return left, false
+		}
+	}
+	if numeric(left) && numeric(right) {
+		return interpreter.FLOAT64, false
+	}
+	if strict {
+		return interpreter.VOID, false
+	}
+
+	if nullish(left) && nullish(right) {
+		return interpreter.VOID, true
+	}
+	if nullish(left) || nullish(right) {
+		return interpreter.VOID, false
+	}
+	if (left == interpreter.BOOL || left == interpreter.INT32) && (right == interpreter.BOOL || right == interpreter.INT32) {
+		return interpreter.INT32, false
+	}
+	return interpreter.FLOAT64, false
+}
+
+// getRelationalType returns the type both operands are converted to before ordering them.
+func (c *Compiler) getRelationalType(left, right interpreter.Type) interpreter.Type {
+	if left == interpreter.STRING || right == interpreter.STRING {
+		if left == interpreter.UNKNOWN || right == interpreter.UNKNOWN {
+			return interpreter.UNKNOWN
+		}
+	} else if left == interpreter.UNKNOWN && right == interpreter.UNKNOWN {
+		return interpreter.UNKNOWN
+	}
+
+	integral := func(typ interpreter.Type) bool {
+		return typ == interpreter.NULL || typ == interpreter.BOOL || typ == interpreter.INT32
+	}
+
+	if left == interpreter.STRING && right == interpreter.STRING {
+		return interpreter.STRING
+	}
+	if integral(left) && integral(right) {
+		return interpreter.INT32
+	}
+	return interpreter.FLOAT64
+}
+
 func (c *Compiler) getAssignmentExpression(node *ast.AssignmentExpression) interpreter.Type {
-	return c.getType(node.Right)
+	if _, ok := logicals[node.Token.Type]; ok {
+		return c.getType(c.logical(node))
+	}
+	return c.getType(c.assignment(node))
 }
 
 func (c *Compiler) getNullLiteralType(_ *ast.NullLiteral) interpreter.Type {
@@ -435,15 +3207,52 @@ func (c *Compiler) getStringLiteralType(_ *ast.StringLiteral) interpreter.Type {
 }
 
 func (c *Compiler) getIdentifierLiteralType(node *ast.IdentifierLiteral) interpreter.Type {
-	sym, ok := c.symbolTable.Resolve(node.Value)
+	sym, t, ok := c.symbolTable.Lookup(node.Value)
 	if !ok {
-		return interpreter.UNDEFINED
+		if typ, ok := interpreter.Builtin(interpreter.String(node.Value)); ok {
+			return typ
+		}
+		return interpreter.UNKNOWN
+	}
+	// Properties of the global object may change the globals it holds at any time.
+	if sym.Captured || t.Frame() != c.symbolTable.Frame() || t == t.Root() && (sym.Kind == VAR || sym.Kind == GLOBAL) {
+		return interpreter.UNKNOWN
 	}
 	return sym.Type
 }
 
+// logical expands a logical assignment into an assignment under the operator.
+func (c *Compiler) logical(node *ast.AssignmentExpression) ast.Expression {
+	op := logicals[node.Token.Type]
+	assign := ast.NewAssignmentExpression(token.New(token.ASSIGN, string(token.ASSIGN)), node.Left, node.Right)
+	return ast.NewLogicalExpression(token.New(op, string(op)), node.Left, assign)
+}
+
+// assignment returns the expression whose value is assigned, expanding compound assignments.
+func (c *Compiler) assignment(node *ast.AssignmentExpression) ast.Expression {
+	op, ok := compounds[node.Token.Type]
+	if !ok {
+		return node.Right
+	}
+	return ast.NewInfixExpression(token.New(op, string(op)), node.Left, node.Right)
+}
+
+// unify returns the type values of both types can be converted to without changing them.
+func (c *Compiler) unify(left, right interpreter.Type) interpreter.Type {
+	if left == right {
+		return left
+	}
+	numeric := func(typ interpreter.Type) bool {
+		return typ == interpreter.INT32 || typ == interpreter.FLOAT64
+	}
+	if numeric(left) && numeric(right) {
+		return interpreter.FLOAT64
+	}
+	return interpreter.UNKNOWN
+}
+
 func (c *Compiler) cast(from, to interpreter.Type) error {
-	if from == to {
+	if from == to || to == interpreter.UNKNOWN {
 		return nil
 	}
 	if instructions := casts[from][to]; len(instructions) > 0 {
@@ -454,8 +3263,24 @@ func (c *Compiler) cast(from, to interpreter.Type) error {
 	return fmt.Errorf("no cast path found from %v to %v", from, to)
 }
 
-func (c *Compiler) emit(op bytecode.Opcode, operands ...uint64) {
+func (c *Compiler) emit(op bytecode.Opcode, operands ...uint64) int {
+	if c.pos.IsValid() && (len(c.positions) == 0 || c.positions[len(c.positions)-1].pos != c.pos) {
+		c.positions = append(c.positions, position{index: len(c.instructions), pos: c.pos})
+	}
 	c.instructions = append(c.instructions, bytecode.New(op, operands...))
+	return len(c.instructions) - 1
+}
+
+func (c *Compiler) patch(idx int, operands ...uint64) {
+	c.instructions[idx] = bytecode.New(c.instructions[idx].Opcode(), operands...)
+}
+
+func (c *Compiler) offset() int {
+	offset := 0
+	for _, instruction := range c.instructions {
+		offset += len(instruction)
+	}
+	return offset
 }
 
 func (c *Compiler) store(val []byte) (uint64, uint64) {
@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"fmt"
 	"math"
 	"testing"
 
@@ -16,6 +17,8 @@ func TestCompiler_Compile(t *testing.T) {
 		node         ast.Node
 		instructions []bytecode.Instruction
 		literals     []string
+		handlers     []bytecode.Handler
+		globals      []bytecode.Global
 	}{
 		{
 			node: ast.NewEmptyStatement(),
@@ -56,8 +59,18 @@ func TestCompiler_Compile(t *testing.T) {
 			instructions: []bytecode.Instruction{
 				bytecode.New(bytecode.BOOLLOAD, 1),
 				bytecode.New(bytecode.BOOLTOI32),
-				bytecode.New(bytecode.I32LOAD, uint64(0xFFFFFFFFFFFFFFFF)),
-				bytecode.New(bytecode.I32MUL),
+				bytecode.New(bytecode.I32TOF64),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(-1)),
+				bytecode.New(bytecode.F64MUL),
+			},
+		},
+		{
+			node: ast.NewPrefixExpression(
+				token.New(token.MINUS, "-"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "0"}, 0),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.Copysign(0, -1))),
 			},
 		},
 		{
@@ -303,111 +316,1788 @@ func TestCompiler_Compile(t *testing.T) {
 				bytecode.New(bytecode.F64MOD),
 			},
 		},
+		{
+			node: ast.NewInfixExpression(
+				token.New(token.EXPONENT, "**"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "3"}, 3),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32TOF64),
+				bytecode.New(bytecode.I32LOAD, 3),
+				bytecode.New(bytecode.I32TOF64),
+				bytecode.New(bytecode.F64POW),
+			},
+		},
 
 		{
-			node: ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "abc"}, "abc"),
+			node: ast.NewInfixExpression(
+				token.New(token.LESS_THAN, "<"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2),
+			),
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.STRLOAD, 0, 3),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32LT),
 			},
-			literals: []string{"abc"},
 		},
 		{
 			node: ast.NewInfixExpression(
-				token.New(token.PLUS, "+"),
-				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "foo"}, "foo"),
-				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "bar"}, "bar"),
+				token.New(token.GREATER_THAN_OR_EQUAL, ">="),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1.5"}, 1.5),
+				ast.NewUndefinedLiteral(token.Token{Type: token.UNDEFINED, Literal: "undefined"}),
 			),
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.STRLOAD, 0, 3),
-				bytecode.New(bytecode.STRLOAD, 4, 3),
-				bytecode.New(bytecode.STRADD),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1.5)),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.UNDEFTOF64),
+				bytecode.New(bytecode.F64GE),
 			},
-			literals: []string{"foo", "bar"},
 		},
 		{
-			node: ast.NewExpressionStatement(
-				ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+			node: ast.NewInfixExpression(
+				token.New(token.GREATER_THAN, ">"),
+				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "b"}, "b"),
+				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "a"}, "a"),
 			),
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.STRLOAD, 2, 1),
+				bytecode.New(bytecode.STRGT),
+			},
+			literals: []string{"b", "a"},
+		},
+		{
+			node: ast.NewInfixExpression(
+				token.New(token.EQUAL, "=="),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "1"}, "1"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32TOF64),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.STRTOF64),
+				bytecode.New(bytecode.F64EQ),
+			},
+			literals: []string{"1"},
+		},
+		{
+			node: ast.NewInfixExpression(
+				token.New(token.NOT_EQUAL, "!="),
+				ast.NewBoolLiteral(token.Token{Type: token.TRUE, Literal: "true"}, true),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.BOOLTOI32),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32NE),
+			},
+		},
+		{
+			node: ast.NewInfixExpression(
+				token.New(token.EQUAL, "=="),
+				ast.NewNullLiteral(token.Token{Type: token.NULL, Literal: "null"}),
+				ast.NewUndefinedLiteral(token.Token{Type: token.UNDEFINED, Literal: "undefined"}),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.POP),
 				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.BOOLLOAD, 1),
 			},
 		},
 		{
-			node: ast.NewVariableStatement(
-				token.New(token.VAR, "var"),
-				ast.NewAssignmentExpression(
-					token.New(token.ASSIGN, "="),
-					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
-					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+			node: ast.NewInfixExpression(
+				token.New(token.IDENTITY_EQUAL, "==="),
+				ast.NewNullLiteral(token.Token{Type: token.NULL, Literal: "null"}),
+				ast.NewUndefinedLiteral(token.Token{Type: token.UNDEFINED, Literal: "undefined"}),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.BOOLLOAD, 0),
+			},
+		},
+		{
+			node: ast.NewInfixExpression(
+				token.New(token.IDENTITY_NOT_EQUAL, "!=="),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1.0"}, 1),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32TOF64),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1)),
+				bytecode.New(bytecode.F64NE),
+			},
+		},
+
+		{
+			node: ast.NewPrefixExpression(
+				token.New(token.NOT, "!"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32TOBOOL),
+				bytecode.New(bytecode.BOOLNOT),
+			},
+		},
+		{
+			node: ast.NewLogicalExpression(
+				token.New(token.AND, "&&"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.I32TOBOOL),
+				bytecode.New(bytecode.JMPF, 18),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.I32LOAD, 2),
+			},
+		},
+		{
+			node: ast.NewLogicalExpression(
+				token.New(token.OR, "||"),
+				ast.NewBoolLiteral(token.Token{Type: token.FALSE, Literal: "false"}, false),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 0),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.JMPT, 14),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.I32LOAD, 2),
+			},
+		},
+
+		{
+			node: ast.NewPrefixExpression(
+				token.New(token.NOT, "!"),
+				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "a"}, "a"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.STRTOBOOL),
+				bytecode.New(bytecode.BOOLNOT),
+			},
+			literals: []string{"a"},
+		},
+		{
+			node: ast.NewPrefixExpression(
+				token.New(token.NOT, "!"),
+				ast.NewLogicalExpression(
+					token.New(token.OR, "||"),
+					ast.NewNullLiteral(token.Token{Type: token.NULL, Literal: "null"}),
+					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1.5"}, 1.5),
 				),
 			),
 			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.NULLTOBOOL),
+				bytecode.New(bytecode.JMPT, 18),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1.5)),
+				bytecode.New(bytecode.ANYTOBOOL),
+				bytecode.New(bytecode.BOOLNOT),
+			},
+		},
+		{
+			node: ast.NewLogicalExpression(
+				token.New(token.NULLISH, "??"),
+				ast.NewNullLiteral(token.Token{Type: token.NULL, Literal: "null"}),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.ANYNULLISH),
+				bytecode.New(bytecode.JMPF, 14),
+				bytecode.New(bytecode.POP),
 				bytecode.New(bytecode.I32LOAD, 1),
-				bytecode.New(bytecode.SLTSTORE, 0),
+			},
+		},
+		{
+			node: ast.NewChainExpression(
+				ast.NewMemberExpression(
+					token.New(token.OPTIONAL_CHAIN, "?."),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "b"}, "b"),
+					false,
+					true,
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
 				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.ANYNULLISH),
+				bytecode.New(bytecode.JMPT, 36),
+				bytecode.New(bytecode.STRLOAD, 2, 1),
+				bytecode.New(bytecode.ANYGET),
+				bytecode.New(bytecode.JMP, 38),
 				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.UNDEFLOAD),
 			},
+			literals: []string{"a", "b"},
+			globals:  []bytecode.Global{{Name: "a", Index: 0}},
 		},
 		{
-			node: ast.NewExpressionStatement(
-				ast.NewAssignmentExpression(
-					token.New(token.ASSIGN, "="),
-					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+			node: ast.NewArrayLiteral(
+				token.New(token.OPEN_BRACKET, "["),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				nil,
+				ast.NewSpreadElement(
+					token.New(token.ELLIPSIS, "..."),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.ARRNEW),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ARRPUSH),
+				bytecode.New(bytecode.ARRHOLE),
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.ARRSPREAD),
+			},
+			literals: []string{"a"},
+			globals:  []bytecode.Global{{Name: "a", Index: 0}},
+		},
+		{
+			node: ast.NewObjectLiteral(
+				token.New(token.OPEN_BRACE, "{"),
+				ast.NewProperty(
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
 					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					ast.INIT,
+					false,
+					false,
+					false,
+				),
+				ast.NewProperty(
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "b"}, "b"),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "b"}, "b"),
+					ast.INIT,
+					false,
+					true,
+					false,
 				),
 			),
 			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.OBJNEW),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
 				bytecode.New(bytecode.I32LOAD, 1),
-				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.OBJPUT),
+				bytecode.New(bytecode.STRLOAD, 2, 1),
+				bytecode.New(bytecode.GLBCHECK, 0, 2, 1),
 				bytecode.New(bytecode.SLTLOAD, 0),
-				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.OBJPUT),
 			},
+			literals: []string{"a", "b"},
+			globals:  []bytecode.Global{{Name: "b", Index: 0}},
 		},
 		{
-			node: ast.NewBlockStatement(
-				ast.NewVariableStatement(
-					token.New(token.VAR, "var"),
-					ast.NewAssignmentExpression(
-						token.New(token.ASSIGN, "="),
-						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
-						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+			node: ast.NewObjectLiteral(
+				token.New(token.OPEN_BRACE, "{"),
+				ast.NewProperty(
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
+					ast.NewFunctionExpression(
+						token.New(token.FUNCTION, "function"),
+						nil,
+						nil,
+						ast.NewBlockStatement(),
 					),
+					ast.GET,
+					false,
+					false,
+					true,
 				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.OBJNEW),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.JMP, 17),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 15, 0, 0, 2, 5, 0),
+				bytecode.New(bytecode.OBJGETTER, 6),
+			},
+			literals: []string{"a", "get a"},
+		},
+		{
+			node: ast.NewProgram(
 				ast.NewExpressionStatement(
-					ast.NewInfixExpression(
-						token.New(token.PLUS, "+"),
-						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
-						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
-					),
+					ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "use strict"}, "use strict"),
 				),
 			),
 			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRICT),
+				bytecode.New(bytecode.STRLOAD, 0, 10),
+				bytecode.New(bytecode.POP),
+			},
+			literals: []string{"use strict"},
+		},
+		{
+			node: ast.NewAssignmentExpression(
+				token.New(token.PLUS_ASSIGN, "+="),
+				ast.NewMemberExpression(
+					token.New(token.DOT, "."),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "b"}, "b"),
+					false,
+					false,
+				),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.STRLOAD, 2, 1),
+				bytecode.New(bytecode.DUP2),
+				bytecode.New(bytecode.ANYGET),
 				bytecode.New(bytecode.I32LOAD, 1),
-				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.ANYADD),
+				bytecode.New(bytecode.ANYSET),
+			},
+			literals: []string{"a", "b"},
+			globals:  []bytecode.Global{{Name: "a", Index: 0}},
+		},
+		{
+			node: ast.NewInfixExpression(
+				token.New(token.PLUS, "+"),
+				ast.NewMemberExpression(
+					token.New(token.DOT, "."),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "b"}, "b"),
+					false,
+					false,
+				),
+				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "c"}, "c"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.STRLOAD, 2, 1),
+				bytecode.New(bytecode.ANYGET),
+				bytecode.New(bytecode.STRLOAD, 4, 1),
+				bytecode.New(bytecode.ANYADD),
+			},
+			literals: []string{"a", "b", "c"},
+			globals:  []bytecode.Global{{Name: "a", Index: 0}},
+		},
+		{
+			node: ast.NewUpdateExpression(
+				token.New(token.PLUS_PLUS, "++"),
+				ast.NewMemberExpression(
+					token.New(token.DOT, "."),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "b"}, "b"),
+					false,
+					false,
+				),
+				false,
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
 				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.STRLOAD, 2, 1),
+				bytecode.New(bytecode.DUP2),
+				bytecode.New(bytecode.ANYGET),
+				bytecode.New(bytecode.ANYTOF64),
+				bytecode.New(bytecode.INSERT, 2),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1)),
+				bytecode.New(bytecode.F64ADD),
+				bytecode.New(bytecode.ANYSET),
 				bytecode.New(bytecode.POP),
+			},
+			literals: []string{"a", "b"},
+			globals:  []bytecode.Global{{Name: "a", Index: 0}},
+		},
+		{
+			node: ast.NewPrefixExpression(
+				token.New(token.DELETE, "delete"),
+				ast.NewMemberExpression(
+					token.New(token.OPEN_BRACKET, "["),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
+					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					true,
+					false,
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
 				bytecode.New(bytecode.SLTLOAD, 0),
 				bytecode.New(bytecode.I32LOAD, 1),
-				bytecode.New(bytecode.I32ADD),
-				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.ANYDELETE),
 			},
+			literals: []string{"a"},
+			globals:  []bytecode.Global{{Name: "a", Index: 0}},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.node.String(), func(t *testing.T) {
-			compiler := New()
-
-			expected := bytecode.Bytecode{}
-			expected.Emit(tt.instructions...)
-			for _, c := range tt.literals {
-				expected.Store([]byte(c + "\x00"))
-			}
-
-			actual, err := compiler.Compile(tt.node)
-			assert.NoError(t, err)
-			assert.Equal(t, expected.String(), actual.String())
-		})
-	}
+		{
+			node: ast.NewCallExpression(
+				token.New(token.OPEN_PAREN, "("),
+				ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "f"}, "f"),
+				[]ast.Expression{ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1)},
+				false,
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.CALL, 1),
+			},
+			literals: []string{"f"},
+			globals:  []bytecode.Global{{Name: "f", Index: 0}},
+		},
+		{
+			node: ast.NewCallExpression(
+				token.New(token.OPEN_PAREN, "("),
+				ast.NewMemberExpression(
+					token.New(token.DOT, "."),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
+					ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "f"}, "f"),
+					false,
+					false,
+				),
+				[]ast.Expression{ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1)},
+				false,
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.STRLOAD, 2, 1),
+				bytecode.New(bytecode.ANYGET),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.CALL, 1),
+			},
+			literals: []string{"a", "f"},
+			globals:  []bytecode.Global{{Name: "a", Index: 0}},
+		},
+		{
+			node: ast.NewNewExpression(
+				token.New(token.NEW, "new"),
+				ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "F"}, "F"),
+				[]ast.Expression{ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1)},
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.NEW, 1),
+			},
+			literals: []string{"F"},
+			globals:  []bytecode.Global{{Name: "F", Index: 0}},
+		},
+		{
+			node: ast.NewInfixExpression(
+				token.New(token.INSTANCEOF, "instanceof"),
+				ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
+				ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "Object"}, "Object"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.BUILTINLOAD, 2, 6),
+				bytecode.New(bytecode.ANYINSTANCEOF),
+			},
+			literals: []string{"a", "Object"},
+			globals:  []bytecode.Global{{Name: "a", Index: 0}},
+		},
+		{
+			node: ast.NewSequenceExpression(
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "a"}, "a"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+			},
+			literals: []string{"a"},
+		},
+		{
+			node: ast.NewPrefixExpression(
+				token.New(token.TYPEOF, "typeof"),
+				ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.ANYTYPEOF),
+			},
+			globals: []bytecode.Global{{Name: "a", Index: 0}},
+		},
+		{
+			node: ast.NewPrefixExpression(
+				token.New(token.VOID, "void"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "0"}, 0),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.UNDEFLOAD),
+			},
+		},
+		{
+			node: ast.NewPrefixExpression(
+				token.New(token.DELETE, "delete"),
+				ast.NewIdentifierLiteral(token.Token{Type: token.IDENTIFIER, Literal: "a"}, "a"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+			},
+		},
+		{
+			node: ast.NewPrefixExpression(
+				token.New(token.DELETE, "delete"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.BOOLLOAD, 1),
+			},
+		},
+		{
+			node: ast.NewIfStatement(
+				token.New(token.IF, "if"),
+				ast.NewUndefinedLiteral(token.Token{Type: token.UNDEFINED, Literal: "undefined"}),
+				ast.NewExpressionStatement(
+					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				),
+				nil,
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.UNDEFTOBOOL),
+				bytecode.New(bytecode.JMPF, 13),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.POP),
+			},
+		},
+		{
+			node: ast.NewIfStatement(
+				token.New(token.IF, "if"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1.5"}, 1.5),
+				ast.NewExpressionStatement(
+					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				),
+				ast.NewExpressionStatement(
+					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1.5)),
+				bytecode.New(bytecode.F64TOBOOL),
+				bytecode.New(bytecode.JMPF, 26),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.JMP, 32),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.POP),
+			},
+		},
+		{
+			node: ast.NewConditionalExpression(
+				token.New(token.QUESTION, "?"),
+				ast.NewBoolLiteral(token.Token{Type: token.TRUE, Literal: "true"}, true),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2.5"}, 2.5),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.JMPF, 18),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32TOF64),
+				bytecode.New(bytecode.JMP, 27),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(2.5)),
+			},
+		},
+		{
+			node: ast.NewConditionalExpression(
+				token.New(token.QUESTION, "?"),
+				ast.NewBoolLiteral(token.Token{Type: token.TRUE, Literal: "true"}, true),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "a"}, "a"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.JMPF, 17),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.JMP, 26),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+			},
+			literals: []string{"a"},
+		},
+		{
+			node: ast.NewUpdateExpression(token.New(token.PLUS_PLUS, "++"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"), false),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 3),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.ANYTOF64),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1)),
+				bytecode.New(bytecode.F64ADD),
+				bytecode.New(bytecode.SLTSTORE, 0),
+			},
+			literals: []string{"foo"},
+			globals:  []bytecode.Global{{Name: "foo", Index: 0}},
+		},
+		{
+			node: ast.NewBlockStatement(
+				ast.NewExpressionStatement(
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+						ast.NewBoolLiteral(token.Token{Type: token.TRUE, Literal: "true"}, true),
+					),
+				),
+				ast.NewExpressionStatement(
+					ast.NewUpdateExpression(token.New(token.MINUS_MINUS, "--"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"), true),
+				),
+				ast.NewExpressionStatement(
+					ast.NewAssignmentExpression(
+						token.New(token.PLUS_ASSIGN, "+="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 3),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.ANYTOF64),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1)),
+				bytecode.New(bytecode.F64SUB),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 3),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.ANYADD),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+			},
+			literals: []string{"foo"},
+			globals:  []bytecode.Global{{Name: "foo", Index: 0}},
+		},
+		{
+			node: ast.NewBlockStatement(
+				ast.NewVariableStatement(
+					token.New(token.LET, "let"),
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+						ast.NewBoolLiteral(token.Token{Type: token.TRUE, Literal: "true"}, true),
+					),
+				),
+				ast.NewExpressionStatement(
+					ast.NewUpdateExpression(token.New(token.MINUS_MINUS, "--"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"), true),
+				),
+				ast.NewExpressionStatement(
+					ast.NewAssignmentExpression(
+						token.New(token.PLUS_ASSIGN, "+="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.SLTCLEAR, 0),
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.BOOLTOI32),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32SUB),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32ADD),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+			},
+		},
+		{
+			node: ast.NewInfixExpression(
+				token.New(token.BIT_OR, "|"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1.5"}, 1.5),
+				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "2"}, "2"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1.5)),
+				bytecode.New(bytecode.F64TOI32),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.STRTOI32),
+				bytecode.New(bytecode.I32OR),
+			},
+			literals: []string{"2"},
+		},
+		{
+			node: ast.NewPrefixExpression(
+				token.New(token.BIT_NOT, "~"),
+				ast.NewUndefinedLiteral(token.Token{Type: token.UNDEFINED, Literal: "undefined"}),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.UNDEFTOF64),
+				bytecode.New(bytecode.F64TOI32),
+				bytecode.New(bytecode.I32NOT),
+			},
+		},
+		{
+			node: ast.NewWhileStatement(
+				token.New(token.WHILE, "while"),
+				ast.NewBoolLiteral(token.Token{Type: token.TRUE, Literal: "true"}, true),
+				ast.NewBreakStatement(token.New(token.BREAK, "break"), nil),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.JMPF, 17),
+				bytecode.New(bytecode.JMP, 17),
+				bytecode.New(bytecode.JMP, 0),
+			},
+		},
+		{
+			node: ast.NewBlockStatement(
+				ast.NewExpressionStatement(
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					),
+				),
+				ast.NewWhileStatement(
+					token.New(token.WHILE, "while"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+					ast.NewExpressionStatement(
+						ast.NewAssignmentExpression(
+							token.New(token.ASSIGN, "="),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+							ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "a"}, "a"),
+						),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 3),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.ANYTOBOOL),
+				bytecode.New(bytecode.JMPF, 53),
+				bytecode.New(bytecode.STRLOAD, 4, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.JMP, 12),
+			},
+			literals: []string{"foo", "a"},
+			globals:  []bytecode.Global{{Name: "foo", Index: 0}},
+		},
+		{
+			node: ast.NewForStatement(
+				token.New(token.FOR, "for"),
+				ast.NewExpressionStatement(
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "0"}, 0),
+					),
+				),
+				ast.NewInfixExpression(
+					token.New(token.LESS_THAN, "<"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2),
+				),
+				ast.NewAssignmentExpression(
+					token.New(token.ASSIGN, "="),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+					ast.NewInfixExpression(
+						token.New(token.PLUS, "+"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					),
+				),
+				ast.NewContinueStatement(token.New(token.CONTINUE, "continue"), nil),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 3),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.ANYTOF64),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32TOF64),
+				bytecode.New(bytecode.F64LT),
+				bytecode.New(bytecode.JMPF, 76),
+				bytecode.New(bytecode.JMP, 44),
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 3),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ANYADD),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.JMP, 12),
+			},
+			literals: []string{"foo"},
+			globals:  []bytecode.Global{{Name: "foo", Index: 0}},
+		},
+		{
+			node: ast.NewLabeledStatement(
+				ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+				ast.NewWhileStatement(
+					token.New(token.WHILE, "while"),
+					ast.NewBoolLiteral(token.Token{Type: token.TRUE, Literal: "true"}, true),
+					ast.NewWhileStatement(
+						token.New(token.WHILE, "while"),
+						ast.NewBoolLiteral(token.Token{Type: token.TRUE, Literal: "true"}, true),
+						ast.NewContinueStatement(token.New(token.CONTINUE, "continue"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo")),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.JMPF, 29),
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.JMPF, 24),
+				bytecode.New(bytecode.JMP, 0),
+				bytecode.New(bytecode.JMP, 7),
+				bytecode.New(bytecode.JMP, 0),
+			},
+		},
+		{
+			node: ast.NewLabeledStatement(
+				ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+				ast.NewBlockStatement(
+					ast.NewBreakStatement(token.New(token.BREAK, "break"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo")),
+					ast.NewExpressionStatement(
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 11),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.POP),
+			},
+		},
+		{
+			node: ast.NewSwitchStatement(
+				token.New(token.SWITCH, "switch"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				ast.NewSwitchCase(
+					token.New(token.CASE, "case"),
+					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					ast.NewBreakStatement(token.New(token.BREAK, "break"), nil),
+				),
+				ast.NewSwitchCase(
+					token.New(token.DEFAULT, "default"),
+					nil,
+					ast.NewExpressionStatement(ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2)),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32EQ),
+				bytecode.New(bytecode.JMPF, 23),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.JMP, 29),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.JMP, 34),
+				bytecode.New(bytecode.JMP, 40),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.POP),
+			},
+		},
+		{
+			node: ast.NewSwitchStatement(
+				token.New(token.SWITCH, "switch"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "0"}, 0),
+				ast.NewSwitchCase(token.New(token.CASE, "case"), ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "0"}, 0)),
+				ast.NewSwitchCase(token.New(token.CASE, "case"), ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1)),
+				ast.NewSwitchCase(token.New(token.CASE, "case"), ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2)),
+				ast.NewSwitchCase(
+					token.New(token.CASE, "case"),
+					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "4"}, 4),
+					ast.NewExpressionStatement(ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1)),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.I32SWITCH, 0, 5),
+				bytecode.New(bytecode.JMP, 50),
+				bytecode.New(bytecode.JMP, 44),
+				bytecode.New(bytecode.JMP, 44),
+				bytecode.New(bytecode.JMP, 44),
+				bytecode.New(bytecode.JMP, 50),
+				bytecode.New(bytecode.JMP, 44),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.POP),
+			},
+		},
+		{
+			node: ast.NewBlockStatement(
+				ast.NewIfStatement(
+					token.New(token.IF, "if"),
+					ast.NewBoolLiteral(token.Token{Type: token.TRUE, Literal: "true"}, true),
+					ast.NewExpressionStatement(
+						ast.NewAssignmentExpression(
+							token.New(token.ASSIGN, "="),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+							ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+						),
+					),
+					nil,
+				),
+				ast.NewExpressionStatement(
+					ast.NewPrefixExpression(
+						token.New(token.NOT, "!"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.JMPF, 19),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 3),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.ANYTOBOOL),
+				bytecode.New(bytecode.BOOLNOT),
+				bytecode.New(bytecode.POP),
+			},
+			literals: []string{"foo"},
+			globals:  []bytecode.Global{{Name: "foo", Index: 0}},
+		},
+
+		{
+			node: ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "abc"}, "abc"),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 3),
+			},
+			literals: []string{"abc"},
+		},
+		{
+			node: ast.NewInfixExpression(
+				token.New(token.PLUS, "+"),
+				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "foo"}, "foo"),
+				ast.NewStringLiteral(token.Token{Type: token.STRING, Literal: "bar"}, "bar"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 3),
+				bytecode.New(bytecode.STRLOAD, 4, 3),
+				bytecode.New(bytecode.STRADD),
+			},
+			literals: []string{"foo", "bar"},
+		},
+		{
+			node: ast.NewExpressionStatement(
+				ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 3),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+			},
+			literals: []string{"foo"},
+			globals:  []bytecode.Global{{Name: "foo", Index: 0}},
+		},
+		{
+			node: ast.NewVariableStatement(
+				token.New(token.VAR, "var"),
+				ast.NewAssignmentExpression(
+					token.New(token.ASSIGN, "="),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+			},
+			globals: []bytecode.Global{{Name: "foo", Index: 0}},
+		},
+		{
+			node: ast.NewExpressionStatement(
+				ast.NewAssignmentExpression(
+					token.New(token.ASSIGN, "="),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+			},
+			globals: []bytecode.Global{{Name: "foo", Index: 0}},
+		},
+		{
+			node: ast.NewBlockStatement(
+				ast.NewVariableStatement(
+					token.New(token.VAR, "var"),
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					),
+				),
+				ast.NewExpressionStatement(
+					ast.NewInfixExpression(
+						token.New(token.PLUS, "+"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ANYADD),
+				bytecode.New(bytecode.POP),
+			},
+			globals: []bytecode.Global{{Name: "foo", Index: 0}},
+		},
+		{
+			node: ast.NewProgram(
+				ast.NewBlockStatement(
+					ast.NewVariableStatement(
+						token.New(token.LET, "let"),
+						ast.NewAssignmentExpression(
+							token.New(token.ASSIGN, "="),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+						),
+					),
+				),
+				ast.NewBlockStatement(
+					ast.NewVariableStatement(
+						token.New(token.CONST, "const"),
+						ast.NewAssignmentExpression(
+							token.New(token.ASSIGN, "="),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+							ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2),
+						),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.SLTCLEAR, 0),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTCLEAR, 0),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.SLTSTORE, 0),
+			},
+		},
+		{
+			node: ast.NewBlockStatement(
+				ast.NewExpressionStatement(
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+				),
+				ast.NewVariableStatement(
+					token.New(token.LET, "let"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+				),
+				ast.NewExpressionStatement(
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.SLTCLEAR, 0),
+				bytecode.New(bytecode.SLTCHECK, 0, 0, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+			},
+			literals: []string{"a"},
+		},
+		{
+			node: ast.NewProgram(
+				ast.NewFunctionDeclaration(
+					ast.NewFunctionExpression(
+						token.New(token.FUNCTION, "function"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "f"), "f"),
+						[]ast.Expression{
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						},
+						ast.NewBlockStatement(
+							ast.NewReturnStatement(
+								token.New(token.RETURN, "return"),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							),
+						),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 11),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 5, 1, 1, 0, 1, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+			},
+			literals: []string{"f"},
+			globals:  []bytecode.Global{{Name: "f", Index: 0}},
+		},
+		{
+			node: ast.NewProgram(
+				ast.NewVariableStatement(
+					token.New(token.VAR, "var"),
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					),
+				),
+				ast.NewExpressionStatement(
+					ast.NewFunctionExpression(
+						token.New(token.FUNCTION, "function"),
+						nil,
+						nil,
+						ast.NewBlockStatement(
+							ast.NewReturnStatement(
+								token.New(token.RETURN, "return"),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							),
+						),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.JMP, 23),
+				bytecode.New(bytecode.GLBLOAD, 0),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 17, 0, 0, 0, 0, 1),
+				bytecode.New(bytecode.POP),
+			},
+			literals: []string{""},
+			globals:  []bytecode.Global{{Name: "a", Index: 0}},
+		},
+		{
+			node: ast.NewProgram(
+				ast.NewFunctionDeclaration(
+					ast.NewFunctionExpression(
+						token.New(token.FUNCTION, "function"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "f"), "f"),
+						[]ast.Expression{
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						},
+						ast.NewBlockStatement(
+							ast.NewReturnStatement(
+								token.New(token.RETURN, "return"),
+								ast.NewFunctionExpression(
+									token.New(token.FUNCTION, "function"),
+									nil,
+									nil,
+									ast.NewBlockStatement(
+										ast.NewReturnStatement(
+											token.New(token.RETURN, "return"),
+											ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+										),
+									),
+								),
+							),
+						),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 39),
+				bytecode.New(bytecode.JMP, 16),
+				bytecode.New(bytecode.UPVLOAD, 0),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 10, 0, 0, 0, 0, 1),
+				bytecode.New(bytecode.FNCAPTURE, 0, 1),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 5, 1, 1, 1, 1, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+			},
+			literals: []string{"", "f"},
+			globals:  []bytecode.Global{{Name: "f", Index: 0}},
+		},
+		{
+			node: ast.NewForStatement(
+				token.New(token.FOR, "for"),
+				ast.NewVariableStatement(
+					token.New(token.LET, "let"),
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "i"), "i"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "0"}, 0),
+					),
+				),
+				nil,
+				nil,
+				ast.NewExpressionStatement(
+					ast.NewFunctionExpression(
+						token.New(token.FUNCTION, "function"),
+						nil,
+						nil,
+						ast.NewBlockStatement(
+							ast.NewReturnStatement(
+								token.New(token.RETURN, "return"),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "i"), "i"),
+							),
+						),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.SLTCLEAR, 0),
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.CELLSTORE, 0),
+				bytecode.New(bytecode.SLTBOX, 0),
+				bytecode.New(bytecode.JMP, 25),
+				bytecode.New(bytecode.UPVLOAD, 0),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 19, 0, 0, 0, 0, 1),
+				bytecode.New(bytecode.FNCAPTURE, 0, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.SLTBOX, 0),
+				bytecode.New(bytecode.JMP, 14),
+			},
+			literals: []string{""},
+		},
+		{
+			node: ast.NewArrowFunctionExpression(
+				token.New(token.ARROW, "=>"),
+				[]ast.Expression{
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+				},
+				ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 11),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 5, 1, 1, 0, 0, 0),
+			},
+			literals: []string{""},
+		},
+		{
+			node: ast.NewProgram(
+				ast.NewFunctionDeclaration(
+					ast.NewFunctionExpression(
+						token.New(token.FUNCTION, "function"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "f"), "f"),
+						nil,
+						ast.NewBlockStatement(
+							ast.NewReturnStatement(
+								token.New(token.RETURN, "return"),
+								ast.NewArrowFunctionExpression(
+									token.New(token.ARROW, "=>"),
+									nil,
+									ast.NewThisExpression(token.New(token.THIS, "this")),
+								),
+							),
+						),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 43),
+				bytecode.New(bytecode.THISLOAD),
+				bytecode.New(bytecode.CELLSTORE, 1),
+				bytecode.New(bytecode.JMP, 20),
+				bytecode.New(bytecode.UPVLOAD, 0),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 14, 0, 0, 0, 0, 0),
+				bytecode.New(bytecode.FNCAPTURE, 0, 1),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 5, 0, 0, 1, 1, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+			},
+			literals: []string{"", "f"},
+			globals:  []bytecode.Global{{Name: "f", Index: 0}},
+		},
+		{
+			node: ast.NewArrowFunctionExpression(
+				token.New(token.ARROW, "=>"),
+				[]ast.Expression{
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					),
+				},
+				ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 29),
+				bytecode.New(bytecode.SLTLOAD, 2),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.ANYSTRICTEQ),
+				bytecode.New(bytecode.JMPF, 23),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 2),
+				bytecode.New(bytecode.SLTLOAD, 2),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 5, 2, 1, 0, 0, 0),
+			},
+			literals: []string{""},
+		},
+		{
+			node: ast.NewArrowFunctionExpression(
+				token.New(token.ARROW, "=>"),
+				[]ast.Expression{
+					ast.NewRestElement(
+						token.New(token.ELLIPSIS, "..."),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+					),
+				},
+				ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 15),
+				bytecode.New(bytecode.RESTLOAD),
+				bytecode.New(bytecode.SLTSTORE, 1),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 5, 0, 0, 0, 0, 0),
+			},
+			literals: []string{""},
+		},
+		{
+			node: ast.NewArrowFunctionExpression(
+				token.New(token.ARROW, "=>"),
+				[]ast.Expression{
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "f"), "f"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+				},
+				ast.NewCallExpression(
+					token.New(token.OPEN_PAREN, "("),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "f"), "f"),
+					[]ast.Expression{
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+						ast.NewSpreadElement(
+							token.New(token.ELLIPSIS, "..."),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						),
+					},
+					false,
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 24),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.ARRNEW),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ARRPUSH),
+				bytecode.New(bytecode.SLTLOAD, 2),
+				bytecode.New(bytecode.ARRSPREAD),
+				bytecode.New(bytecode.APPLY),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 5, 2, 2, 0, 0, 0),
+			},
+			literals: []string{""},
+		},
+		{
+			node: ast.NewProgram(
+				ast.NewClassDeclaration(
+					ast.NewClassExpression(
+						token.New(token.CLASS, "class"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "A"), "A"),
+						nil,
+						nil,
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.SLTCLEAR, 0),
+				bytecode.New(bytecode.SLTCLEAR, 1),
+				bytecode.New(bytecode.JMP, 14),
+				bytecode.New(bytecode.STRICT),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 11, 0, 0, 0, 1, 3),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.STRLOAD, 2, 9),
+				bytecode.New(bytecode.ANYGET),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.SLTSTORE, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+			},
+			literals: []string{"A", "prototype"},
+		},
+		{
+			node: ast.NewClassExpression(
+				token.New(token.CLASS, "class"),
+				nil,
+				nil,
+				[]ast.Node{
+					ast.NewMethodDefinition(
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "x"), "x"),
+						ast.NewFunctionExpression(
+							token.New(token.FUNCTION, "function"),
+							nil,
+							nil,
+							ast.NewBlockStatement(),
+						),
+						ast.GET,
+						false,
+						true,
+					),
+				},
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 8),
+				bytecode.New(bytecode.STRICT),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 5, 0, 0, 0, 0, 3),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.STRLOAD, 1, 9),
+				bytecode.New(bytecode.ANYGET),
+				bytecode.New(bytecode.DUP2),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.STRLOAD, 11, 1),
+				bytecode.New(bytecode.JMP, 54),
+				bytecode.New(bytecode.STRICT),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 51, 0, 0, 13, 5, 0),
+				bytecode.New(bytecode.OBJGETTER, 4),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.POP),
+			},
+			literals: []string{"", "prototype", "x", "get x"},
+		},
+		{
+			node: ast.NewClassExpression(
+				token.New(token.CLASS, "class"),
+				nil,
+				ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "B"), "B"),
+				[]ast.Node{
+					ast.NewPropertyDefinition(
+						ast.NewPrivateIdentifier(token.New(token.PRIVATE_IDENTIFIER, "#x"), "#x"),
+						nil,
+						false,
+						false,
+					),
+				},
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 2),
+				bytecode.New(bytecode.PRIVNEW),
+				bytecode.New(bytecode.CELLSTORE, 0),
+				bytecode.New(bytecode.GLBCHECK, 1, 3, 1),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.JMP, 49),
+				bytecode.New(bytecode.STRICT),
+				bytecode.New(bytecode.RESTLOAD),
+				bytecode.New(bytecode.SLTSTORE, 1),
+				bytecode.New(bytecode.ARRNEW),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.ARRSPREAD),
+				bytecode.New(bytecode.SUPERAPPLY),
+				bytecode.New(bytecode.THISINIT),
+				bytecode.New(bytecode.THISFIELDS),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 32, 0, 0, 5, 0, 7),
+				bytecode.New(bytecode.FNEXTEND),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.STRLOAD, 6, 9),
+				bytecode.New(bytecode.ANYGET),
+				bytecode.New(bytecode.JMP, 92),
+				bytecode.New(bytecode.STRICT),
+				bytecode.New(bytecode.THISLOAD),
+				bytecode.New(bytecode.UPVLOAD, 0),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.OBJPUT),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 82, 0, 0, 5, 0, 0),
+				bytecode.New(bytecode.FNCAPTURE, 0, 0),
+				bytecode.New(bytecode.FNHOME),
+				bytecode.New(bytecode.FNFIELDS),
+			},
+			literals: []string{"#x", "B", "", "prototype"},
+			globals:  []bytecode.Global{{Name: "B", Index: 1}},
+		},
+		{
+			node: ast.NewProgram(
+				ast.NewTryStatement(
+					token.New(token.TRY, "try"),
+					ast.NewBlockStatement(
+						ast.NewThrowStatement(token.New(token.THROW, "throw"), ast.NewNumberLiteral(token.New(token.NUMBER, "1"), 1)),
+					),
+					ast.NewCatchClause(
+						token.New(token.CATCH, "catch"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "e"), "e"),
+						ast.NewBlockStatement(
+							ast.NewExpressionStatement(ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "e"), "e")),
+						),
+					),
+					nil,
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.THROW),
+				bytecode.New(bytecode.JMP, 26),
+				bytecode.New(bytecode.SLTCLEAR, 0),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.JMP, 26),
+			},
+			handlers: []bytecode.Handler{{Start: 0, End: 6, Target: 11}},
+		},
+		{
+			node: ast.NewProgram(
+				ast.NewVariableStatement(
+					token.New(token.LET, "let"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+				),
+				ast.NewTryStatement(
+					token.New(token.TRY, "try"),
+					ast.NewBlockStatement(),
+					nil,
+					ast.NewBlockStatement(
+						ast.NewExpressionStatement(ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a")),
+					),
+				),
+			),
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.SLTCLEAR, 0),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.JMP, 27),
+				bytecode.New(bytecode.SLTSTORE, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.THROW),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.node.String(), func(t *testing.T) {
+			compiler := New()
+
+			expected := bytecode.Bytecode{}
+			expected.Emit(tt.instructions...)
+			for _, c := range tt.literals {
+				expected.Store([]byte(c + "\x00"))
+			}
+			expected.Handlers = tt.handlers
+			expected.Globals = tt.globals
+
+			actual, err := compiler.Compile(tt.node)
+			assert.NoError(t, err)
+			assert.Equal(t, expected.String(), actual.String())
+		})
+	}
+}
+
+func TestCompiler_CompileError(t *testing.T) {
+	tests := []struct {
+		node ast.Node
+	}{
+		{
+			node: ast.NewBreakStatement(token.New(token.BREAK, "break"), nil),
+		},
+		{
+			node: ast.NewContinueStatement(token.New(token.CONTINUE, "continue"), nil),
+		},
+		{
+			node: ast.NewWhileStatement(
+				token.New(token.WHILE, "while"),
+				ast.NewBoolLiteral(token.Token{Type: token.TRUE, Literal: "true"}, true),
+				ast.NewBreakStatement(token.New(token.BREAK, "break"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo")),
+			),
+		},
+		{
+			node: ast.NewLabeledStatement(
+				ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+				ast.NewBlockStatement(
+					ast.NewContinueStatement(token.New(token.CONTINUE, "continue"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo")),
+				),
+			),
+		},
+		{
+			node: ast.NewSwitchStatement(
+				token.New(token.SWITCH, "switch"),
+				ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+				ast.NewSwitchCase(
+					token.New(token.CASE, "case"),
+					ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					ast.NewContinueStatement(token.New(token.CONTINUE, "continue"), nil),
+				),
+			),
+		},
+		{
+			node: ast.NewLabeledStatement(
+				ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+				ast.NewLabeledStatement(
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "foo"), "foo"),
+					ast.NewEmptyStatement(),
+				),
+			),
+		},
+		{
+			node: ast.NewBlockStatement(
+				ast.NewVariableStatement(
+					token.New(token.CONST, "const"),
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "1"}, 1),
+					),
+				),
+				ast.NewExpressionStatement(
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewNumberLiteral(token.Token{Type: token.NUMBER, Literal: "2"}, 2),
+					),
+				),
+			),
+		},
+		{
+			node: ast.NewBlockStatement(
+				ast.NewVariableStatement(
+					token.New(token.LET, "let"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+				),
+				ast.NewVariableStatement(
+					token.New(token.VAR, "var"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+				),
+			),
+		},
+		{
+			node: ast.NewReturnStatement(
+				token.New(token.RETURN, "return"),
+				nil,
+			),
+		},
+		{
+			node: ast.NewCallExpression(
+				token.New(token.OPEN_PAREN, "("),
+				ast.NewSuperExpression(token.New(token.SUPER, "super")),
+				nil,
+				false,
+			),
+		},
+		{
+			node: ast.NewMemberExpression(
+				token.New(token.DOT, "."),
+				ast.NewThisExpression(token.New(token.THIS, "this")),
+				ast.NewPrivateIdentifier(token.New(token.PRIVATE_IDENTIFIER, "#x"), "#x"),
+				false,
+				false,
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.node.String(), func(t *testing.T) {
+			compiler := New()
+
+			_, err := compiler.Compile(tt.node)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCompiler_CompileTooManyVariables(t *testing.T) {
+	var statements []ast.Statement
+	for i := 0; i <= math.MaxUint16; i++ {
+		name := fmt.Sprintf("v%d", i)
+		statements = append(statements, ast.NewVariableStatement(
+			token.New(token.VAR, "var"),
+			ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, name), name),
+		))
+	}
+
+	compiler := New()
+
+	_, err := compiler.Compile(ast.NewProgram(statements...))
+	assert.Error(t, err)
+
+	_, err = compiler.Compile(ast.NewProgram(ast.NewVariableStatement(
+		token.New(token.VAR, "var"),
+		ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+	)))
+	assert.NoError(t, err)
 }
@@ -1,32 +1,231 @@
 package compiler
 
 import (
+	"fmt"
+
 	"github.com/siyul-park/minijs/internal/interpreter"
 )
 
+type Kind int
+
+const (
+	VAR Kind = iota
+	LET
+	CONST
+	// GLOBAL is the kind of names referred to without a declaration.
+	GLOBAL
+)
+
 type Symbol struct {
-	Name  string
-	Index int
-	Type  interpreter.Type
+	Name        string
+	Index       int
+	Type        interpreter.Type
+	Kind        Kind
+	Initialized bool
+	Captured    bool
 }
 
 type SymbolTable struct {
-	symbols map[string]*Symbol
+	outer    *SymbolTable
+	symbols  map[string]*Symbol
+	captures map[string]bool
+	size     *int
+	peak     *int
+	base     int
 }
 
 func NewSymbolTable() *SymbolTable {
 	return &SymbolTable{
 		symbols: make(map[string]*Symbol),
+		size:    new(int),
+		peak:    new(int),
 	}
 }
 
+func NewEnclosedSymbolTable(outer *SymbolTable) *SymbolTable {
+	return &SymbolTable{
+		outer:   outer,
+		symbols: make(map[string]*Symbol),
+		size:    outer.size,
+		peak:    outer.peak,
+		base:    *outer.size,
+	}
+}
+
+func NewFrameSymbolTable(outer *SymbolTable) *SymbolTable {
+	return &SymbolTable{
+		outer:   outer,
+		symbols: make(map[string]*Symbol),
+		size:    new(int),
+		peak:    new(int),
+	}
+}
+
+func (s *SymbolTable) Outer() *SymbolTable {
+	return s.outer
+}
+
+func (s *SymbolTable) Root() *SymbolTable {
+	root := s
+	for root.outer != nil {
+		root = root.outer
+	}
+	return root
+}
+
+// Frame returns the outermost table sharing the slots of the table, which holds var declarations.
+func (s *SymbolTable) Frame() *SymbolTable {
+	frame := s
+	for frame.outer != nil && frame.outer.size == s.size {
+		frame = frame.outer
+	}
+	return frame
+}
+
+// Capture marks the symbols defined in the frame under the names as captured by nested functions.
+func (s *SymbolTable) Capture(names ...string) {
+	frame := s.Frame()
+	if frame.captures == nil {
+		frame.captures = make(map[string]bool)
+	}
+	for _, name := range names {
+		frame.captures[name] = true
+	}
+}
+
+// Define defines the name in a new slot, where symbols of the frame never take a slot freed by a block scope.
 func (s *SymbolTable) Define(name string) *Symbol {
-	sym := &Symbol{Name: name, Index: len(s.symbols)}
+	if s == s.Frame() {
+		*s.size = *s.peak
+	}
+	sym := &Symbol{Name: name, Index: *s.size, Initialized: true, Captured: s.Frame().captures[name]}
+	*s.size++
+	*s.peak = max(*s.peak, *s.size)
 	s.symbols[name] = sym
 	return sym
 }
 
-func (s *SymbolTable) Resolve(name string) (*Symbol, bool) {
+// Declare defines a declaration of the kind, where only var declarations may be repeated.
+func (s *SymbolTable) Declare(name string, kind Kind) (*Symbol, error) {
+	if kind == VAR {
+		for t := s; t != nil && t.size == s.size; t = t.outer {
+			if sym, ok := t.symbols[name]; ok {
+				if sym.Kind == GLOBAL {
+					sym.Kind = VAR
+				}
+				if sym.Kind != VAR {
+					return nil, fmt.Errorf("identifier '%s' has already been declared", name)
+				}
+				return sym, nil
+			}
+		}
+		return s.Frame().Define(name), nil
+	}
+
 	sym, ok := s.symbols[name]
+	if ok && sym.Kind != GLOBAL {
+		return nil, fmt.Errorf("identifier '%s' has already been declared", name)
+	}
+	if !ok {
+		sym = s.Define(name)
+	}
+	sym.Kind = kind
+	sym.Initialized = false
+	return sym, nil
+}
+
+// Global refers to the name as a global yet to be created, which a later declaration takes over.
+func (s *SymbolTable) Global(name string) *Symbol {
+	sym := s.Root().Define(name)
+	sym.Type = interpreter.UNKNOWN
+	sym.Kind = GLOBAL
+	sym.Initialized = false
+	return sym
+}
+
+func (s *SymbolTable) Resolve(name string) (*Symbol, bool) {
+	sym, _, ok := s.Lookup(name)
 	return sym, ok
 }
+
+func (s *SymbolTable) Lookup(name string) (*Symbol, *SymbolTable, bool) {
+	for t := s; t != nil; t = t.outer {
+		if sym, ok := t.symbols[name]; ok {
+			return sym, t, true
+		}
+	}
+	return nil, nil, false
+}
+
+// Size returns the number of slots ever taken in the frame of the table.
+func (s *SymbolTable) Size() int {
+	return *s.peak
+}
+
+// Free releases the slots of the table so that the following scopes can reuse them.
+func (s *SymbolTable) Free() {
+	size := s.base
+	for t := s.outer; t != nil && t.size == s.size; t = t.outer {
+		for _, sym := range t.symbols {
+			if sym.Index >= size {
+				size = sym.Index + 1
+			}
+		}
+	}
+	*s.size = size
+}
+
+// Types returns the current type of every symbol.
+func (s *SymbolTable) Types() map[*Symbol]interpreter.Type {
+	types := make(map[*Symbol]interpreter.Type)
+	for t := s; t != nil; t = t.outer {
+		for _, sym := range t.symbols {
+			types[sym] = sym.Type
+		}
+	}
+	return types
+}
+
+// Restore resets the types to ones captured before entering another control flow path.
+func (s *SymbolTable) Restore(types map[*Symbol]interpreter.Type) {
+	for t := s; t != nil; t = t.outer {
+		for _, sym := range t.symbols {
+			typ, ok := types[sym]
+			if !ok {
+				typ = interpreter.UNDEFINED
+			}
+			sym.Type = typ
+		}
+	}
+}
+
+// Merge joins the current types with types captured on another control flow path.
+func (s *SymbolTable) Merge(types map[*Symbol]interpreter.Type) {
+	for t := s; t != nil; t = t.outer {
+		for _, sym := range t.symbols {
+			typ, ok := types[sym]
+			if !ok {
+				typ = interpreter.UNDEFINED
+			}
+			if sym.Type != typ {
+				sym.Type = interpreter.UNKNOWN
+			}
+		}
+	}
+}
+
+// Changed reports whether any type differs from ones captured before.
+func (s *SymbolTable) Changed(types map[*Symbol]interpreter.Type) bool {
+	for t := s; t != nil; t = t.outer {
+		for _, sym := range t.symbols {
+			typ, ok := types[sym]
+			if !ok {
+				typ = interpreter.UNDEFINED
+			}
+			if sym.Type != typ {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package interpreter
+
+// Arguments is the array-like object holding the arguments a function is called with.
+type Arguments struct {
+	values []Value
+	object *Object
+}
+
+func (a *Arguments) Len() int {
+	return len(a.values)
+}
+
+func (a *Arguments) At(idx int) Value {
+	return a.values[idx]
+}
+
+func (a *Arguments) SetAt(idx int, val Value) {
+	a.values[idx] = val
+}
+
+func (a *Arguments) Object() *Object {
+	return a.object
+}
+
+func (a *Arguments) Type() Type {
+	return OBJECT
+}
+
+func (a *Arguments) Interface() any {
+	return a
+}
+
+func (a *Arguments) String() string {
+	return inspect(a, nil)
+}
@@ -0,0 +1,194 @@
+package interpreter
+
+import (
+	"iter"
+	"maps"
+	"math"
+	"slices"
+)
+
+// Array is an ordered list of values, which falls back to a map when an index goes far beyond the length.
+type Array struct {
+	elements []Value
+	sparse   map[uint32]Value
+	length   uint32
+	object   *Object
+}
+
+// maxSpread limits the elements an array may be spread into.
+const maxSpread = 1 << 24
+
+// gap is how far beyond the length an index may go before the array turns sparse.
+const gap = 1 << 10
+
+func (a *Array) Len() int {
+	if a.sparse == nil {
+		return len(a.elements)
+	}
+	return int(a.length)
+}
+
+// At returns the element at the index, which is undefined for holes.
+func (a *Array) At(idx uint32) Value {
+	if val, ok := a.Index(idx); ok {
+		return val
+	}
+	return Undefined{}
+}
+
+func (a *Array) Index(idx uint32) (Value, bool) {
+	if a.sparse == nil {
+		if int64(idx) >= int64(len(a.elements)) || a.elements[idx] == nil {
+			return nil, false
+		}
+		return a.elements[idx], true
+	}
+	val, ok := a.sparse[idx]
+	return val, ok
+}
+
+func (a *Array) SetIndex(idx uint32, val Value) {
+	if a.sparse == nil {
+		switch n := len(a.elements); {
+		case int64(idx) < int64(n):
+			a.elements[idx] = val
+			return
+		case int64(idx) <= int64(n)+gap:
+			a.elements = append(a.elements, make([]Value, int(idx)-n)...)
+			a.elements = append(a.elements, val)
+			return
+		default:
+			a.sparsify()
+		}
+	}
+	a.sparse[idx] = val
+	if idx >= a.length {
+		a.length = idx + 1
+	}
+}
+
+func (a *Array) DeleteIndex(idx uint32) {
+	if a.sparse == nil {
+		if int64(idx) < int64(len(a.elements)) {
+			a.elements[idx] = nil
+		}
+		return
+	}
+	delete(a.sparse, idx)
+}
+
+// SetLen changes the length, removing the elements past it when shrinking.
+func (a *Array) SetLen(n uint32) {
+	if a.sparse == nil {
+		switch l := len(a.elements); {
+		case int64(n) <= int64(l):
+			clear(a.elements[n:])
+			a.elements = a.elements[:n]
+			return
+		case int64(n) <= int64(l)+gap:
+			a.elements = append(a.elements, make([]Value, int(n)-l)...)
+			return
+		default:
+			a.sparsify()
+		}
+	}
+	for idx := range a.sparse {
+		if idx >= n {
+			delete(a.sparse, idx)
+		}
+	}
+	a.length = n
+}
+
+func (a *Array) Push(vals ...Value) {
+	if a.sparse == nil {
+		a.elements = append(a.elements, vals...)
+		return
+	}
+	for _, val := range vals {
+		a.SetIndex(a.length, val)
+	}
+}
+
+// Values yields every element up to the length, with undefined in place of holes.
+func (a *Array) Values() iter.Seq[Value] {
+	return func(yield func(Value) bool) {
+		next := uint32(0)
+		for _, idx := range a.Indices() {
+			for ; next < idx; next++ {
+				if !yield(Undefined{}) {
+					return
+				}
+			}
+			if !yield(a.At(idx)) {
+				return
+			}
+			next = idx + 1
+		}
+		for ; int64(next) < int64(a.Len()); next++ {
+			if !yield(Undefined{}) {
+				return
+			}
+		}
+	}
+}
+
+// Indices returns the indices of the elements other than holes in ascending order.
+func (a *Array) Indices() []uint32 {
+	if a.sparse != nil {
+		return slices.Sorted(maps.Keys(a.sparse))
+	}
+	indices := make([]uint32, 0, len(a.elements))
+	for i, val := range a.elements {
+		if val != nil {
+			indices = append(indices, uint32(i))
+		}
+	}
+	return indices
+}
+
+func (a *Array) Object() *Object {
+	return a.object
+}
+
+func (a *Array) Type() Type {
+	return OBJECT
+}
+
+func (a *Array) Interface() any {
+	return a
+}
+
+func (a *Array) String() string {
+	return inspect(a, nil)
+}
+
+func (a *Array) sparsify() {
+	a.sparse = make(map[uint32]Value, len(a.elements))
+	for i, val := range a.elements {
+		if val != nil {
+			a.sparse[uint32(i)] = val
+		}
+	}
+	a.length = uint32(len(a.elements))
+	a.elements = nil
+}
+
+func arrayIndex(key Value) (uint32, bool) {
+	switch key := key.(type) {
+	case Int32:
+		return uint32(key), key >= 0
+	case Float64:
+		if f := float64(key); f >= 0 && f < math.MaxUint32 && f == math.Trunc(f) {
+			return uint32(f), true
+		}
+	default:
+	}
+	return index(ToPropertyKey(key))
+}
+
+// arrayLength converts the value to the length of an array, which must be an unsigned 32-bit integer.
+func arrayLength(val Value) (uint32, bool) {
+	n := ToUint32(val)
+	return n, float64(n) == float64(ToNumber(val))
+}
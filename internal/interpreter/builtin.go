@@ -0,0 +1,255 @@
+package interpreter
+
+import "fmt"
+
+// objects builds the constructor of objects and the methods of the built-in prototypes.
+func (r *Realm) objects() {
+	constructor := r.NewNativeFunction("Object", 1, CONSTRUCTOR, func(this Value, args []Value) (Value, error) {
+		if obj, ok := this.(*Object); ok && obj.Prototype() != Value(r.ObjectPrototype) {
+			return obj, nil
+		}
+		if len(args) == 0 || IsNullish(args[0]) {
+			return NewObject(r.ObjectPrototype), nil
+		}
+		return args[0], nil
+	})
+	constructor.Object().Define("prototype", r.ObjectPrototype)
+	r.ObjectPrototype.Define("constructor", constructor)
+
+	getter := r.NewNativeFunction("get __proto__", 0, 0, func(this Value, _ []Value) (Value, error) {
+		if IsNullish(this) {
+			return nil, fmt.Errorf("cannot convert %s to object", string(ToString(this)))
+		}
+		return r.Prototype(this), nil
+	})
+	setter := r.NewNativeFunction("set __proto__", 1, 0, func(this Value, args []Value) (Value, error) {
+		if IsNullish(this) {
+			return nil, fmt.Errorf("cannot convert %s to object", string(ToString(this)))
+		}
+		if proto := argument(args, 0); proto.Type() == NULL || object(proto) {
+			return Undefined{}, SetPrototype(this, proto)
+		}
+		return Undefined{}, nil
+	})
+	DefineAccessor(r.ObjectPrototype, String("__proto__"), getter, setter, CONFIGURABLE)
+
+	r.method(r.ObjectPrototype, "valueOf", 0, func(this Value, _ []Value) (Value, error) {
+		if IsNullish(this) {
+			return nil, fmt.Errorf("cannot convert %s to object", string(ToString(this)))
+		}
+		return this, nil
+	})
+	r.method(r.ObjectPrototype, "toString", 0, func(this Value, _ []Value) (Value, error) {
+		return "[object " + tag(this) + "]", nil
+	})
+	r.method(r.ArrayPrototype, "toString", 0, func(this Value, _ []Value) (Value, error) {
+		if arr, ok := this.(*Array); ok {
+			return ToString(arr), nil
+		}
+		return "[object " + tag(this) + "]", nil
+	})
+	r.method(r.FunctionPrototype, "toString", 0, func(this Value, _ []Value) (Value, error) {
+		fn, ok := this.(*Function)
+		if !ok {
+			return nil, fmt.Errorf("Function.prototype.toString requires that 'this' be a Function")
+		}
+		return ToString(fn), nil
+	})
+
+	r.method(constructor, "getPrototypeOf", 1, func(_ Value, args []Value) (Value, error) {
+		val := argument(args, 0)
+		if IsNullish(val) {
+			return nil, fmt.Errorf("cannot convert %s to object", string(ToString(val)))
+		}
+		return r.Prototype(val), nil
+	})
+	r.method(constructor, "setPrototypeOf", 2, func(_ Value, args []Value) (Value, error) {
+		val, proto := argument(args, 0), argument(args, 1)
+		if IsNullish(val) {
+			return nil, fmt.Errorf("Object.setPrototypeOf called on null or undefined")
+		}
+		if _, ok := proto.(Null); !ok && !object(proto) {
+			return nil, fmt.Errorf("object prototype may only be an Object or null: %s", string(ToString(proto)))
+		}
+		return val, SetPrototype(val, proto)
+	})
+	r.method(constructor, "defineProperty", 3, func(_ Value, args []Value) (Value, error) {
+		val, key := argument(args, 0), argument(args, 1)
+		if !object(val) {
+			return nil, fmt.Errorf("Object.defineProperty called on non-object")
+		}
+		desc, err := descriptor(argument(args, 2))
+		if err != nil {
+			return nil, err
+		}
+		return val, DefineProperty(val, ToPropertyKey(key), desc)
+	})
+	r.method(constructor, "defineProperties", 2, func(_ Value, args []Value) (Value, error) {
+		val, props := argument(args, 0), argument(args, 1)
+		if !object(val) {
+			return nil, fmt.Errorf("Object.defineProperties called on non-object")
+		}
+		if IsNullish(props) {
+			return nil, fmt.Errorf("cannot convert %s to object", string(ToString(props)))
+		}
+		var keys []String
+		var descs []Descriptor
+		for _, key := range OwnKeys(props) {
+			if attributes(props, key)&ENUMERABLE == 0 {
+				continue
+			}
+			prop, _ := own(props, key)
+			desc, err := descriptor(prop)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+			descs = append(descs, desc)
+		}
+		for i, key := range keys {
+			if err := DefineProperty(val, key, descs[i]); err != nil {
+				return nil, err
+			}
+		}
+		return val, nil
+	})
+	r.method(constructor, "getOwnPropertyDescriptor", 2, func(_ Value, args []Value) (Value, error) {
+		val, key := argument(args, 0), argument(args, 1)
+		if IsNullish(val) {
+			return nil, fmt.Errorf("cannot convert %s to object", string(ToString(val)))
+		}
+		desc, ok := GetOwnProperty(val, ToPropertyKey(key))
+		if !ok {
+			return Undefined{}, nil
+		}
+		obj := NewObject(r.ObjectPrototype)
+		if desc.Accessor() {
+			obj.Set("get", desc.Getter)
+			obj.Set("set", desc.Setter)
+		} else {
+			obj.Set("value", desc.Value)
+			obj.Set("writable", NewBool(desc.Attributes&WRITABLE != 0))
+		}
+		obj.Set("enumerable", NewBool(desc.Attributes&ENUMERABLE != 0))
+		obj.Set("configurable", NewBool(desc.Attributes&CONFIGURABLE != 0))
+		return obj, nil
+	})
+	r.method(constructor, "preventExtensions", 1, func(_ Value, args []Value) (Value, error) {
+		val := argument(args, 0)
+		PreventExtensions(val)
+		return val, nil
+	})
+	r.method(constructor, "seal", 1, func(_ Value, args []Value) (Value, error) {
+		val := argument(args, 0)
+		Seal(val)
+		return val, nil
+	})
+	r.method(constructor, "freeze", 1, func(_ Value, args []Value) (Value, error) {
+		val := argument(args, 0)
+		Freeze(val)
+		return val, nil
+	})
+	r.method(constructor, "isExtensible", 1, func(_ Value, args []Value) (Value, error) {
+		return NewBool(IsExtensible(argument(args, 0))), nil
+	})
+	r.method(constructor, "isSealed", 1, func(_ Value, args []Value) (Value, error) {
+		return NewBool(IsSealed(argument(args, 0))), nil
+	})
+	r.method(constructor, "isFrozen", 1, func(_ Value, args []Value) (Value, error) {
+		return NewBool(IsFrozen(argument(args, 0))), nil
+	})
+	r.method(constructor, "is", 2, func(_ Value, args []Value) (Value, error) {
+		return NewBool(same(argument(args, 0), argument(args, 1))), nil
+	})
+
+	r.globals["Object"] = constructor
+}
+
+// tag names the kind of the value as the string form of objects describes it.
+func tag(val Value) String {
+	switch val := val.(type) {
+	case Undefined:
+		return "Undefined"
+	case Null:
+		return "Null"
+	case Bool:
+		return "Boolean"
+	case Int32, Float64:
+		return "Number"
+	case String:
+		return "String"
+	case *Array:
+		return "Array"
+	case *Function:
+		return "Function"
+	case *Arguments:
+		return "Arguments"
+	case *Object:
+		if val.errorData {
+			return "Error"
+		}
+	}
+	return "Object"
+}
+
+func argument(args []Value, idx int) Value {
+	if idx < len(args) {
+		return args[idx]
+	}
+	return Undefined{}
+}
+
+// descriptor converts the object into a descriptor of a property, reading accessors as undefined.
+func descriptor(val Value) (Descriptor, error) {
+	if !object(val) {
+		return Descriptor{}, fmt.Errorf("property description must be an object: %s", string(ToString(val)))
+	}
+	var desc Descriptor
+	for _, f := range []struct {
+		name String
+		attr Attribute
+	}{{"enumerable", ENUMERABLE}, {"configurable", CONFIGURABLE}, {"writable", WRITABLE}} {
+		if v, ok := field(val, f.name); ok {
+			desc.Mask |= f.attr
+			if ToBool(v) != 0 {
+				desc.Attributes |= f.attr
+			}
+		}
+	}
+	if v, ok := field(val, "value"); ok {
+		desc.Value = v
+	}
+	for _, name := range []String{"get", "set"} {
+		v, ok := field(val, name)
+		if !ok {
+			continue
+		}
+		if _, ok := v.(*Function); !ok {
+			if _, ok := v.(Undefined); !ok {
+				return Descriptor{}, fmt.Errorf("%ster must be a function: %s", string(name), string(ToString(v)))
+			}
+		}
+		if name == "get" {
+			desc.Getter = v
+		} else {
+			desc.Setter = v
+		}
+	}
+	if desc.Accessor() && desc.Data() {
+		return Descriptor{}, fmt.Errorf("invalid property descriptor. Cannot both specify accessors and a value or writable attribute")
+	}
+	return desc, nil
+}
+
+// field reads the property of the object along its chain, reporting whether it has it.
+func field(val Value, key String) (Value, bool) {
+	for obj := val; !IsNullish(obj); obj = Prototype(obj) {
+		if prop, ok := own(obj, key); ok {
+			if _, ok := prop.(*Accessor); ok {
+				return Undefined{}, true
+			}
+			return prop, true
+		}
+	}
+	return nil, false
+}
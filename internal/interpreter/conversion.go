@@ -0,0 +1,168 @@
+package interpreter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ToBool converts a value to a boolean following the ToBoolean rules of ECMAScript.
+func ToBool(val Value) Bool {
+	switch val := val.(type) {
+	case nil, Undefined, Null:
+		return Bool(0)
+	case Bool:
+		return val
+	case Int32:
+		return NewBool(val != 0)
+	case Float64:
+		return NewBool(val != 0 && !math.IsNaN(float64(val)))
+	case String:
+		return NewBool(len(val) > 0)
+	default:
+		return Bool(1)
+	}
+}
+
+// ToNumber converts a value to a number following the ToNumber rules of ECMAScript.
+func ToNumber(val Value) Float64 {
+	switch val := val.(type) {
+	case nil, Undefined:
+		return Float64(math.NaN())
+	case Null:
+		return Float64(0)
+	case Bool:
+		return Float64(val)
+	case Int32:
+		return Float64(val)
+	case Float64:
+		return val
+	case String:
+		return Float64(parseNumber(string(val)))
+	default:
+		return Float64(parseNumber(string(ToString(val))))
+	}
+}
+
+// ToInt32 converts a value to a signed 32-bit integer following the ToInt32 rules of ECMAScript.
+func ToInt32(val Value) Int32 {
+	return Int32(ToUint32(val))
+}
+
+// ToUint32 converts a value to an unsigned 32-bit integer following the ToUint32 rules of ECMAScript.
+func ToUint32(val Value) uint32 {
+	if val, ok := val.(Int32); ok {
+		return uint32(val)
+	}
+
+	f := float64(ToNumber(val))
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0
+	}
+	return uint32(int64(math.Mod(math.Trunc(f), 1<<32)))
+}
+
+// ToString converts a value to a string following the ToString rules of ECMAScript.
+func ToString(val Value) String {
+	switch val := val.(type) {
+	case nil, Undefined:
+		return String(Undefined{}.String())
+	case Null:
+		return String(val.String())
+	case Bool:
+		return String(val.String())
+	case Int32:
+		return String(val.String())
+	case Float64:
+		return String(val.String())
+	case String:
+		return val
+	case *Function:
+		return String("function " + string(val.Name()) + "() { [native code] }")
+	case *Array:
+		elements := make([]string, 0, val.Len())
+		for elem := range val.Values() {
+			if IsNullish(elem) {
+				elements = append(elements, "")
+			} else {
+				elements = append(elements, string(ToString(elem)))
+			}
+		}
+		return String(strings.Join(elements, ","))
+	case *Arguments:
+		return String("[object Arguments]")
+	case *Object:
+		return String("[object Object]")
+	case *Private:
+		return val.Name()
+	default:
+		return String("")
+	}
+}
+
+// ToPrimitive converts an object to its string form without calling back into the program.
+func ToPrimitive(val Value) Value {
+	if object(val) {
+		return ToString(val)
+	}
+	return val
+}
+
+// ToPropertyKey converts a value to the key of a property following the ToPropertyKey rules of ECMAScript.
+func ToPropertyKey(val Value) String {
+	return ToString(val)
+}
+
+// object reports whether the value is an object, functions included.
+func object(val Value) bool {
+	return val != nil && (val.Type() == OBJECT || val.Type() == FUNCTION)
+}
+
+// parseNumber converts a string to a number following the StringToNumber rules of ECMAScript.
+func parseNumber(s string) float64 {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0
+	}
+
+	switch s {
+	case "Infinity", "+Infinity":
+		return math.Inf(1)
+	case "-Infinity":
+		return math.Inf(-1)
+	}
+
+	if len(s) > 2 && s[0] == '0' {
+		base := 0
+		switch s[1] {
+		case 'x', 'X':
+			base = 16
+		case 'o', 'O':
+			base = 8
+		case 'b', 'B':
+			base = 2
+		}
+		if base != 0 {
+			n, err := strconv.ParseUint(s[2:], base, 64)
+			if err != nil || strings.ContainsRune(s[2:], '_') {
+				return math.NaN()
+			}
+			return float64(n)
+		}
+	}
+
+	for _, ch := range s {
+		if (ch < '0' || ch > '9') && ch != '.' && ch != 'e' && ch != 'E' && ch != '+' && ch != '-' {
+			return math.NaN()
+		}
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return f
+		}
+		return math.NaN()
+	}
+	return f
+}
@@ -0,0 +1,148 @@
+package interpreter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fault is a failure of an operation thrown as an error of the kind it names; other failures are type errors.
+type fault struct {
+	name    String
+	message string
+}
+
+func (f *fault) Error() string {
+	return string(f.name) + ": " + f.message
+}
+
+func referenceError(format string, args ...any) error {
+	return &fault{name: "ReferenceError", message: fmt.Sprintf(format, args...)}
+}
+
+func rangeError(format string, args ...any) error {
+	return &fault{name: "RangeError", message: fmt.Sprintf(format, args...)}
+}
+
+// uninitialized fails accessing the variable in its temporal dead zone.
+func uninitialized(name string) error {
+	if name == "this" {
+		return referenceError("must call super constructor in derived class before accessing 'this' or returning from derived constructor")
+	}
+	return referenceError("cannot access '%s' before initialization", name)
+}
+
+// maxTrace limits the calls listed in the stack of an error.
+const maxTrace = 10
+
+func (r *Realm) errorTypes() {
+	r.ErrorPrototype.Define("name", String("Error"))
+	r.ErrorPrototype.Define("message", String(""))
+	r.method(r.ErrorPrototype, "toString", 0, func(this Value, _ []Value) (Value, error) {
+		if !object(this) {
+			return nil, fmt.Errorf("Error.prototype.toString called on non-object")
+		}
+		return describe(this), nil
+	})
+
+	base := r.errorConstructor("Error", r.ErrorPrototype, false)
+	for _, name := range []String{"TypeError", "RangeError", "ReferenceError", "SyntaxError", "EvalError", "URIError", "AggregateError"} {
+		proto := NewObject(r.ErrorPrototype)
+		proto.Define("name", name)
+		proto.Define("message", String(""))
+		constructor := r.errorConstructor(name, proto, name == "AggregateError")
+		constructor.Object().SetPrototype(base)
+	}
+}
+
+// errorConstructor builds the constructor of errors of the prototype, which works with or without new.
+func (r *Realm) errorConstructor(name String, proto *Object, aggregate bool) *Function {
+	length := Int32(1)
+	if aggregate {
+		length = 2
+	}
+	constructor := r.NewNativeFunction(name, length, CONSTRUCTOR, func(this Value, args []Value) (Value, error) {
+		obj, ok := this.(*Object)
+		if !ok || !inherits(obj, proto) {
+			obj = NewObject(proto)
+		}
+		obj.errorData = true
+
+		if aggregate {
+			errors, err := Spread(argument(args, 0))
+			if err != nil {
+				return nil, err
+			}
+			obj.Define("errors", r.NewArray(errors...))
+			args = args[min(1, len(args)):]
+		}
+		if message := argument(args, 0); message.Type() != UNDEFINED {
+			obj.Define("message", ToString(message))
+		}
+		if options := argument(args, 1); object(options) {
+			if cause, ok := field(options, "cause"); ok {
+				obj.Define("cause", cause)
+			}
+		}
+		return obj, nil
+	})
+	constructor.Object().Define("prototype", proto)
+	proto.Define("constructor", constructor)
+
+	r.errors[name] = proto
+	r.globals[name] = constructor
+	return constructor
+}
+
+// describe formats the name of the error followed by its message.
+func describe(val Value) String {
+	name, message := String("Error"), String("")
+	if v, ok := field(val, "name"); ok && v.Type() != UNDEFINED {
+		name = ToString(v)
+	}
+	if v, ok := field(val, "message"); ok && v.Type() != UNDEFINED {
+		message = ToString(v)
+	}
+	switch {
+	case name == "":
+		return message
+	case message == "":
+		return name
+	default:
+		return name + ": " + message
+	}
+}
+
+// trace formats the stack of the error over the calls of the frames from the innermost one.
+func (i *Interpreter) trace(val Value) String {
+	var out strings.Builder
+	out.WriteString(string(describe(val)))
+	for fp := i.fp - 1; fp >= 0 && i.fp-fp <= maxTrace; fp-- {
+		frame := &i.frames[fp]
+
+		name := "<anonymous>"
+		if fp > 0 {
+			if fn, ok := frame.slots[0].(*Function); ok && fn.Name() != "" {
+				name = string(fn.Name())
+			}
+		}
+		out.WriteString("\n    at " + name)
+		if pos, ok := frame.code.Locate(frame.ip); ok {
+			fmt.Fprintf(&out, " (%d:%d)", pos.Line, pos.Column)
+		}
+	}
+	return String(out.String())
+}
+
+// raise turns the failure into an exception, creating an error of its kind unless a value is thrown.
+func (i *Interpreter) raise(err error) *Exception {
+	if e, ok := err.(*Exception); ok {
+		return e
+	}
+	name, message := String("TypeError"), err.Error()
+	if f, ok := err.(*fault); ok {
+		name, message = f.name, f.message
+	}
+	obj := i.realm.NewError(name, String(message))
+	obj.Define("stack", i.trace(obj))
+	return NewException(obj)
+}
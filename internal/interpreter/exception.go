@@ -0,0 +1,20 @@
+package interpreter
+
+import "fmt"
+
+// Exception is the error of a value thrown by a program.
+type Exception struct {
+	value Value
+}
+
+func NewException(val Value) *Exception {
+	return &Exception{value: val}
+}
+
+func (e *Exception) Value() Value {
+	return e.value
+}
+
+func (e *Exception) Error() string {
+	return fmt.Sprintf("Uncaught %v", e.value)
+}
@@ -1,8 +1,27 @@
 package interpreter
 
+import "github.com/siyul-park/minijs/internal/bytecode"
+
 type Frame struct {
-	slots []Value
-	ip    int
+	code     bytecode.Bytecode
+	slots    []Value
+	cells    []*Cell
+	upvalues []*Cell
+	this     Value
+	argc     int
+	rest     []Value
+	// target is the constructor new is applied to, which is set only in the frames it enters.
+	target *Function
+	// result replaces the value the function returns when set.
+	result Value
+	strict bool
+	ip     int
+	bp     int
+}
+
+// Cell holds a variable captured by functions.
+type Cell struct {
+	value Value
 }
 
 func (f *Frame) Slot(idx int) (Value, bool) {
@@ -24,3 +43,29 @@ func (f *Frame) SetSlot(idx int, val Value) {
 	}
 	f.slots[idx] = val
 }
+
+// Cell returns the cell of the slot, moving the value of the slot into it at the first access.
+func (f *Frame) Cell(idx int) *Cell {
+	if len(f.cells) <= idx {
+		cells := make([]*Cell, (idx+1)*2)
+		copy(cells, f.cells)
+		f.cells = cells
+	}
+	if f.cells[idx] == nil {
+		val, _ := f.Slot(idx)
+		f.cells[idx] = &Cell{value: val}
+	}
+	return f.cells[idx]
+}
+
+func (f *Frame) Box(idx int) {
+	val := f.Cell(idx).value
+	f.cells[idx] = &Cell{value: val}
+}
+
+func (f *Frame) Clear(idx int) {
+	f.SetSlot(idx, nil)
+	if idx < len(f.cells) {
+		f.cells[idx] = nil
+	}
+}
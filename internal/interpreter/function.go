@@ -0,0 +1,89 @@
+package interpreter
+
+import "github.com/siyul-park/minijs/internal/bytecode"
+
+// Function is a function value whose body starts at the entry of its code, or is native to Go.
+type Function struct {
+	name        String
+	length      Int32
+	arity       int
+	flags       byte
+	code        bytecode.Bytecode
+	entry       int
+	upvalues    []*Cell
+	native      Native
+	object      *Object
+	home        Value
+	initializer *Function
+	realm       *Realm
+}
+
+// Native is the body of a function implemented in Go.
+type Native func(this Value, args []Value) (Value, error)
+
+const (
+	CONSTRUCTOR byte = 1 << iota
+	CLASS
+	DERIVED
+)
+
+func (f *Function) Name() String {
+	return f.name
+}
+
+func (f *Function) Length() Int32 {
+	return f.length
+}
+
+func (f *Function) Constructor() bool {
+	return f.flags&CONSTRUCTOR != 0
+}
+
+func (f *Function) Class() bool {
+	return f.flags&CLASS != 0
+}
+
+func (f *Function) Derived() bool {
+	return f.flags&DERIVED != 0
+}
+
+// Object holds the properties of the function, creating the prototype of constructors at the first access.
+func (f *Function) Object() *Object {
+	if f.object == nil {
+		f.object = NewObject(f.realm.FunctionPrototype)
+		if f.native == nil && f.Constructor() {
+			proto := NewObject(f.realm.ObjectPrototype)
+			proto.Define("constructor", f)
+			f.object.Define("prototype", proto)
+			if f.Class() {
+				f.home = proto
+			}
+		}
+	}
+	return f.object
+}
+
+func (f *Function) Type() Type {
+	return FUNCTION
+}
+
+func (f *Function) Interface() any {
+	return f
+}
+
+func (f *Function) String() string {
+	if f.Class() {
+		name := "(anonymous)"
+		if f.name != "" {
+			name = string(f.name)
+		}
+		if parent, ok := f.Object().Prototype().(*Function); ok && f.Derived() {
+			return "[class " + name + " extends " + string(parent.name) + "]"
+		}
+		return "[class " + name + "]"
+	}
+	if f.name == "" {
+		return "[Function (anonymous)]"
+	}
+	return "[Function: " + string(f.name) + "]"
+}
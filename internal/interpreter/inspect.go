@@ -0,0 +1,120 @@
+package interpreter
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// inspect formats the value for display, expanding the objects nested in it unless they refer back to themselves.
+func inspect(val Value, seen []Value) string {
+	switch val := val.(type) {
+	case *Object, *Array, *Arguments:
+		if slices.Contains(seen, Value(val)) {
+			return "[Circular]"
+		}
+		seen = append(seen, val)
+	default:
+	}
+
+	switch val := val.(type) {
+	case String:
+		return "'" + string(val) + "'"
+	case *Array:
+		var elements []string
+		next := uint32(0)
+		for _, idx := range val.Indices() {
+			if idx > next {
+				elements = append(elements, empty(idx-next))
+			}
+			elem, _ := val.Index(idx)
+			elements = append(elements, inspect(elem, seen))
+			next = idx + 1
+		}
+		if n := uint32(val.Len()); n > next {
+			elements = append(elements, empty(n-next))
+		}
+		for _, key := range val.Object().Keys() {
+			prop, _ := val.Object().Get(key)
+			elements = append(elements, name(key)+": "+inspect(prop, seen))
+		}
+		if len(elements) == 0 {
+			return "[]"
+		}
+		return "[ " + strings.Join(elements, ", ") + " ]"
+	case *Arguments:
+		return "[Arguments] " + list("[", val.values, "]", seen)
+	case *Function:
+		return val.String()
+	case *Accessor:
+		return val.String()
+	case *Object:
+		prefix := constructor(val)
+		keys := val.Keys()
+		if val.errorData {
+			prefix = string(describe(val))
+			if stack, ok := val.Get("stack"); ok && stack.Type() == STRING {
+				prefix = string(ToString(stack))
+			}
+			if len(keys) == 0 {
+				return prefix
+			}
+			prefix += " "
+		}
+		if len(keys) == 0 {
+			return prefix + "{}"
+		}
+		props := make([]string, 0, len(keys))
+		for _, key := range keys {
+			v, _ := val.Get(key)
+			props = append(props, name(key)+": "+inspect(v, seen))
+		}
+		return prefix + "{ " + strings.Join(props, ", ") + " }"
+	default:
+		return string(ToString(val))
+	}
+}
+
+// constructor names the constructor of the object unless it is a plain one.
+func constructor(obj *Object) string {
+	proto := obj.Prototype()
+	if _, ok := proto.(Null); ok {
+		return "[Object: null prototype] "
+	}
+	ctor, _ := Get(proto, String("constructor"))
+	if fn, ok := ctor.(*Function); ok && fn.Name() != "" && fn.Name() != "Object" {
+		return string(fn.Name()) + " "
+	}
+	return ""
+}
+
+func list(open string, vals []Value, close string, seen []Value) string {
+	if len(vals) == 0 {
+		return open + close
+	}
+	elements := make([]string, 0, len(vals))
+	for _, val := range vals {
+		elements = append(elements, inspect(val, seen))
+	}
+	return open + " " + strings.Join(elements, ", ") + " " + close
+}
+
+func empty(n uint32) string {
+	if n == 1 {
+		return "<1 empty item>"
+	}
+	return "<" + strconv.FormatUint(uint64(n), 10) + " empty items>"
+}
+
+func name(key String) string {
+	for i, r := range string(key) {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return "'" + string(key) + "'"
+		}
+	}
+	if key == "" {
+		return "''"
+	}
+	return string(key)
+}
@@ -4,22 +4,31 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
-	"strconv"
 
 	"github.com/siyul-park/minijs/internal/bytecode"
 )
 
+// maxFrames limits the depth of calls.
+const maxFrames = 10000
+
+// maxArguments limits the arguments an array may be spread into for a call.
+const maxArguments = 1 << 16
+
 type Interpreter struct {
 	stack  []Value
 	frames []Frame
 	sp     int
 	fp     int
+	realm  *Realm
+	bound  map[String]bool
 }
 
 func New() *Interpreter {
 	i := &Interpreter{
 		stack:  make([]Value, 64),
 		frames: make([]Frame, 64),
+		realm:  NewRealm(),
+		bound:  make(map[String]bool),
 	}
 	i.call(Frame{ip: -1})
 	return i
@@ -29,11 +38,42 @@ func (i *Interpreter) Pop() Value {
 	return i.pop()
 }
 
-func (i *Interpreter) Execute(code bytecode.Bytecode) error {
-	instructions := code.Instructions
-	constants := code.Constants
+func (i *Interpreter) Execute(code bytecode.Bytecode) (err error) {
+	fp := i.fp
+	defer func() {
+		if err != nil {
+			for i.fp > fp {
+				i.exit()
+			}
+			i.sp = i.frames[fp-1].bp
+		}
+	}()
+
+	for _, g := range code.Globals {
+		i.bind(String(g.Name), g.Index)
+	}
 
+	i.frames[i.fp-1].code = code
+	i.frames[i.fp-1].strict = false
 	i.frames[i.fp-1].ip = -1
+	i.frames[i.fp-1].bp = i.sp
+	for {
+		err := i.run(fp)
+		if err == nil {
+			return nil
+		}
+		exception := i.raise(err)
+		if !i.catch(exception, fp) {
+			return exception
+		}
+	}
+}
+
+// run executes the current frame until the program ends, the frame at the depth returns, or it fails.
+func (i *Interpreter) run(depth int) error {
+	instructions := i.frames[i.fp-1].code.Instructions
+	constants := i.frames[i.fp-1].code.Constants
+
 	for i.frames[i.fp-1].ip < len(instructions)-1 {
 		i.frames[i.fp-1].ip++
 
@@ -44,6 +84,152 @@ func (i *Interpreter) Execute(code bytecode.Bytecode) error {
 		case bytecode.NOP:
 		case bytecode.POP:
 			i.pop()
+		case bytecode.DUP:
+			i.push(i.peek())
+		case bytecode.DUP2:
+			val1 := i.stack[i.sp-2]
+			val2 := i.stack[i.sp-1]
+			i.push(val1)
+			i.push(val2)
+		case bytecode.INSERT:
+			n := int(instructions[ip+1])
+			val := i.peek()
+			i.push(val)
+			copy(i.stack[i.sp-n-1:i.sp-1], i.stack[i.sp-n-2:i.sp-2])
+			i.stack[i.sp-n-2] = val
+			ip += 1
+		case bytecode.STRICT:
+			i.frames[i.fp-1].strict = true
+		case bytecode.JMP:
+			ip = int(binary.BigEndian.Uint32(instructions[ip+1:])) - 1
+		case bytecode.JMPT:
+			val, _ := i.pop().(Bool)
+			if val != 0 {
+				ip = int(binary.BigEndian.Uint32(instructions[ip+1:])) - 1
+			} else {
+				ip += 4
+			}
+		case bytecode.JMPF:
+			val, _ := i.pop().(Bool)
+			if val == 0 {
+				ip = int(binary.BigEndian.Uint32(instructions[ip+1:])) - 1
+			} else {
+				ip += 4
+			}
+		case bytecode.CALL:
+			i.frames[i.fp-1].ip = ip + 1
+			fn, err := i.invoke(int(instructions[ip+1]))
+			if err != nil {
+				return err
+			}
+			if fn == nil {
+				ip += 1
+				break
+			}
+			instructions = fn.code.Instructions
+			constants = fn.code.Constants
+			ip = fn.entry - 1
+		case bytecode.APPLY:
+			argc, err := i.spread()
+			if err != nil {
+				return err
+			}
+
+			i.frames[i.fp-1].ip = ip
+			fn, err := i.invoke(argc)
+			if err != nil {
+				return err
+			}
+			if fn == nil {
+				break
+			}
+			instructions = fn.code.Instructions
+			constants = fn.code.Constants
+			ip = fn.entry - 1
+		case bytecode.NEW:
+			i.frames[i.fp-1].ip = ip + 1
+			fn, err := i.construct(int(instructions[ip+1]))
+			if err != nil {
+				return err
+			}
+			if fn == nil {
+				ip += 1
+				break
+			}
+			instructions = fn.code.Instructions
+			constants = fn.code.Constants
+			ip = fn.entry - 1
+		case bytecode.NEWAPPLY:
+			argc, err := i.spread()
+			if err != nil {
+				return err
+			}
+
+			i.frames[i.fp-1].ip = ip
+			fn, err := i.construct(argc)
+			if err != nil {
+				return err
+			}
+			if fn == nil {
+				break
+			}
+			instructions = fn.code.Instructions
+			constants = fn.code.Constants
+			ip = fn.entry - 1
+		case bytecode.SUPERCALL:
+			i.frames[i.fp-1].ip = ip + 1
+			fn, err := i.super(int(instructions[ip+1]))
+			if err != nil {
+				return err
+			}
+			if fn == nil {
+				ip += 1
+				break
+			}
+			instructions = fn.code.Instructions
+			constants = fn.code.Constants
+			ip = fn.entry - 1
+		case bytecode.SUPERAPPLY:
+			argc, err := i.spread()
+			if err != nil {
+				return err
+			}
+
+			i.frames[i.fp-1].ip = ip
+			fn, err := i.super(argc)
+			if err != nil {
+				return err
+			}
+			if fn == nil {
+				break
+			}
+			instructions = fn.code.Instructions
+			constants = fn.code.Constants
+			ip = fn.entry - 1
+		case bytecode.RETURN:
+			val := i.pop()
+			callee := i.exit()
+			if callee.result != nil {
+				val = callee.result
+			}
+			if callee.target != nil && !object(val) {
+				if callee.this == nil {
+					return uninitialized("this")
+				}
+				val = callee.this
+			}
+			i.sp = callee.bp
+			i.push(val)
+			if i.fp < depth {
+				return nil
+			}
+
+			frame := i.frames[i.fp-1]
+			instructions = frame.code.Instructions
+			constants = frame.code.Constants
+			ip = frame.ip
+		case bytecode.THROW:
+			return NewException(i.pop())
 		case bytecode.SLTLOAD:
 			idx := binary.BigEndian.Uint16(instructions[ip+1:])
 			var val Value = Undefined{}
@@ -57,8 +243,105 @@ func (i *Interpreter) Execute(code bytecode.Bytecode) error {
 			val := i.pop()
 			i.frames[i.fp-1].SetSlot(int(idx), val)
 			ip += 2
+		case bytecode.SLTCLEAR:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			i.frames[i.fp-1].Clear(int(idx))
+			ip += 2
+		case bytecode.SLTCHECK:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			if _, ok := i.frames[i.fp-1].Slot(int(idx)); !ok {
+				offset := binary.BigEndian.Uint32(instructions[ip+3:])
+				size := binary.BigEndian.Uint32(instructions[ip+7:])
+				return uninitialized(string(constants[offset : offset+size]))
+			}
+			ip += 10
+		case bytecode.SLTBOX:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			i.frames[i.fp-1].Box(int(idx))
+			ip += 2
+		case bytecode.CELLLOAD:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			var val Value = Undefined{}
+			if v := i.frames[i.fp-1].Cell(int(idx)).value; v != nil {
+				val = v
+			}
+			i.push(val)
+			ip += 2
+		case bytecode.CELLSTORE:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			i.frames[i.fp-1].Cell(int(idx)).value = i.pop()
+			ip += 2
+		case bytecode.CELLCHECK:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			if i.frames[i.fp-1].Cell(int(idx)).value == nil {
+				offset := binary.BigEndian.Uint32(instructions[ip+3:])
+				size := binary.BigEndian.Uint32(instructions[ip+7:])
+				return uninitialized(string(constants[offset : offset+size]))
+			}
+			ip += 10
+		case bytecode.UPVLOAD:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			var val Value = Undefined{}
+			if v := i.frames[i.fp-1].upvalues[idx].value; v != nil {
+				val = v
+			}
+			i.push(val)
+			ip += 2
+		case bytecode.UPVSTORE:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			i.frames[i.fp-1].upvalues[idx].value = i.pop()
+			ip += 2
+		case bytecode.UPVCHECK:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			if i.frames[i.fp-1].upvalues[idx].value == nil {
+				offset := binary.BigEndian.Uint32(instructions[ip+3:])
+				size := binary.BigEndian.Uint32(instructions[ip+7:])
+				return uninitialized(string(constants[offset : offset+size]))
+			}
+			ip += 10
+		case bytecode.GLBLOAD:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			var val Value = Undefined{}
+			if v, ok := i.frames[0].Slot(int(idx)); ok {
+				val = v
+			}
+			i.push(val)
+			ip += 2
+		case bytecode.GLBSTORE:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			val := i.pop()
+			i.frames[0].SetSlot(int(idx), val)
+			ip += 2
+		case bytecode.GLBCHECK:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			if _, ok := i.frames[0].Slot(int(idx)); !ok {
+				offset := binary.BigEndian.Uint32(instructions[ip+3:])
+				size := binary.BigEndian.Uint32(instructions[ip+7:])
+				return referenceError("%s is not defined", constants[offset:offset+size])
+			}
+			ip += 10
+		case bytecode.GLBHOLE:
+			idx := binary.BigEndian.Uint16(instructions[ip+1:])
+			if _, ok := i.frames[0].Slot(int(idx)); !ok {
+				offset := binary.BigEndian.Uint32(instructions[ip+3:])
+				size := binary.BigEndian.Uint32(instructions[ip+7:])
+				return uninitialized(string(constants[offset : offset+size]))
+			}
+			ip += 10
+		case bytecode.BUILTINLOAD:
+			offset := int(binary.BigEndian.Uint32(instructions[ip+1:]))
+			size := int(binary.BigEndian.Uint32(instructions[ip+5:]))
+			var val Value = Undefined{}
+			if v, ok := i.realm.Global(String(constants[offset : offset+size])); ok {
+				val = v
+			}
+			i.push(val)
+			ip += 8
 		case bytecode.UNDEFLOAD:
 			i.push(Undefined{})
+		case bytecode.UNDEFTOBOOL:
+			i.pop()
+			i.push(Bool(0))
 		case bytecode.UNDEFTOF64:
 			i.pop()
 			i.push(Float64(math.NaN()))
@@ -67,6 +350,9 @@ func (i *Interpreter) Execute(code bytecode.Bytecode) error {
 			i.push(String(val.String()))
 		case bytecode.NULLLOAD:
 			i.push(Null{})
+		case bytecode.NULLTOBOOL:
+			i.pop()
+			i.push(Bool(0))
 		case bytecode.NULLTOI32:
 			i.pop()
 			i.push(Int32(0))
@@ -83,22 +369,22 @@ func (i *Interpreter) Execute(code bytecode.Bytecode) error {
 		case bytecode.BOOLTOSTR:
 			val, _ := i.pop().(Bool)
 			i.push(String(val.String()))
+		case bytecode.BOOLNOT:
+			val, _ := i.pop().(Bool)
+			i.push(NewBool(val == 0))
 		case bytecode.I32LOAD:
 			val := Int32(binary.BigEndian.Uint32(instructions[ip+1:]))
 			i.push(val)
 			ip += 4
 		case bytecode.I32ADD:
-			val2, _ := i.pop().(Int32)
-			val1, _ := i.pop().(Int32)
-			i.push(val1 + val2)
+			val2, val1 := i.pop(), i.pop()
+			i.push(addInt32(val1, val2))
 		case bytecode.I32SUB:
-			val2, _ := i.pop().(Int32)
-			val1, _ := i.pop().(Int32)
-			i.push(val1 - val2)
+			val2, val1 := i.pop(), i.pop()
+			i.push(subInt32(val1, val2))
 		case bytecode.I32MUL:
-			val2, _ := i.pop().(Int32)
-			val1, _ := i.pop().(Int32)
-			i.push(val1 * val2)
+			val2, val1 := i.pop(), i.pop()
+			i.push(mulInt32(val1, val2))
 		case bytecode.I32DIV:
 			val2, _ := i.pop().(Int32)
 			val1, _ := i.pop().(Int32)
@@ -108,17 +394,59 @@ func (i *Interpreter) Execute(code bytecode.Bytecode) error {
 			val1, _ := i.pop().(Int32)
 			i.push(val1 % val2)
 		case bytecode.I32TOBOOL:
-			val, _ := i.pop().(Int32)
-			if val > 0 {
-				val = 1
-			}
-			i.push(Bool(val))
+			i.push(ToBool(i.pop()))
 		case bytecode.I32TOF64:
-			val, _ := i.pop().(Int32)
-			i.push(Float64(val))
+			i.push(ToNumber(i.pop()))
 		case bytecode.I32TOSTR:
-			val, _ := i.pop().(Int32)
-			i.push(String(val.String()))
+			i.push(ToString(i.pop()))
+		case bytecode.I32EQ:
+			val2, val1 := ToNumber(i.pop()), ToNumber(i.pop())
+			i.push(NewBool(val1 == val2))
+		case bytecode.I32NE:
+			val2, val1 := ToNumber(i.pop()), ToNumber(i.pop())
+			i.push(NewBool(val1 != val2))
+		case bytecode.I32LT:
+			val2, val1 := ToNumber(i.pop()), ToNumber(i.pop())
+			i.push(NewBool(val1 < val2))
+		case bytecode.I32GT:
+			val2, val1 := ToNumber(i.pop()), ToNumber(i.pop())
+			i.push(NewBool(val1 > val2))
+		case bytecode.I32LE:
+			val2, val1 := ToNumber(i.pop()), ToNumber(i.pop())
+			i.push(NewBool(val1 <= val2))
+		case bytecode.I32GE:
+			val2, val1 := ToNumber(i.pop()), ToNumber(i.pop())
+			i.push(NewBool(val1 >= val2))
+		case bytecode.I32AND:
+			val2, val1 := ToInt32(i.pop()), ToInt32(i.pop())
+			i.push(val1 & val2)
+		case bytecode.I32OR:
+			val2, val1 := ToInt32(i.pop()), ToInt32(i.pop())
+			i.push(val1 | val2)
+		case bytecode.I32XOR:
+			val2, val1 := ToInt32(i.pop()), ToInt32(i.pop())
+			i.push(val1 ^ val2)
+		case bytecode.I32NOT:
+			i.push(^ToInt32(i.pop()))
+		case bytecode.I32SHL:
+			val2, val1 := ToUint32(i.pop()), ToInt32(i.pop())
+			i.push(val1 << (val2 & 0x1F))
+		case bytecode.I32SHR:
+			val2, val1 := ToUint32(i.pop()), ToInt32(i.pop())
+			i.push(val1 >> (val2 & 0x1F))
+		case bytecode.I32USHR:
+			val2, val1 := ToUint32(i.pop()), ToUint32(i.pop())
+			i.push(Float64(val1 >> (val2 & 0x1F)))
+		case bytecode.I32SWITCH:
+			val := float64(ToNumber(i.pop()))
+			low := int64(int32(binary.BigEndian.Uint32(instructions[ip+1:])))
+			count := int64(binary.BigEndian.Uint32(instructions[ip+5:]))
+
+			entry := int64(0)
+			if idx := int64(val) - low; val == math.Trunc(val) && idx >= 0 && idx < count {
+				entry = idx + 1
+			}
+			ip += 8 + int(entry)*bytecode.TypeOf(bytecode.JMP).Width()
 		case bytecode.F64LOAD:
 			val := Float64(math.Float64frombits(binary.BigEndian.Uint64(instructions[ip+1:])))
 			i.push(val)
@@ -143,12 +471,43 @@ func (i *Interpreter) Execute(code bytecode.Bytecode) error {
 			val2, _ := i.pop().(Float64)
 			val1, _ := i.pop().(Float64)
 			i.push(Float64(math.Mod(float64(val1), float64(val2))))
+		case bytecode.F64POW:
+			val2, _ := i.pop().(Float64)
+			val1, _ := i.pop().(Float64)
+			i.push(Pow(val1, val2))
+		case bytecode.F64TOBOOL:
+			val, _ := i.pop().(Float64)
+			i.push(NewBool(val != 0 && !math.IsNaN(float64(val))))
 		case bytecode.F64TOI32:
 			val, _ := i.pop().(Float64)
-			i.push(Int32(val))
+			i.push(ToInt32(val))
 		case bytecode.F64TOSTR:
 			val, _ := i.pop().(Float64)
 			i.push(String(val.String()))
+		case bytecode.F64EQ:
+			val2, _ := i.pop().(Float64)
+			val1, _ := i.pop().(Float64)
+			i.push(NewBool(val1 == val2))
+		case bytecode.F64NE:
+			val2, _ := i.pop().(Float64)
+			val1, _ := i.pop().(Float64)
+			i.push(NewBool(val1 != val2))
+		case bytecode.F64LT:
+			val2, _ := i.pop().(Float64)
+			val1, _ := i.pop().(Float64)
+			i.push(NewBool(val1 < val2))
+		case bytecode.F64GT:
+			val2, _ := i.pop().(Float64)
+			val1, _ := i.pop().(Float64)
+			i.push(NewBool(val1 > val2))
+		case bytecode.F64LE:
+			val2, _ := i.pop().(Float64)
+			val1, _ := i.pop().(Float64)
+			i.push(NewBool(val1 <= val2))
+		case bytecode.F64GE:
+			val2, _ := i.pop().(Float64)
+			val1, _ := i.pop().(Float64)
+			i.push(NewBool(val1 >= val2))
 		case bytecode.STRLOAD:
 			offset := int(binary.BigEndian.Uint32(instructions[ip+1:]))
 			size := int(binary.BigEndian.Uint32(instructions[ip+5:]))
@@ -158,20 +517,401 @@ func (i *Interpreter) Execute(code bytecode.Bytecode) error {
 			val2, _ := i.pop().(String)
 			val1, _ := i.pop().(String)
 			i.push(val1 + val2)
+		case bytecode.STRTOBOOL:
+			val, _ := i.pop().(String)
+			i.push(NewBool(len(val) > 0))
 		case bytecode.STRTOI32:
 			val, _ := i.pop().(String)
-			n, err := strconv.Atoi(string(val))
-			if err != nil {
-				n = 0
-			}
-			i.push(Int32(n))
+			i.push(ToInt32(val))
 		case bytecode.STRTOF64:
 			val, _ := i.pop().(String)
-			f, err := strconv.ParseFloat(string(val), 64)
+			i.push(Float64(parseNumber(string(val))))
+		case bytecode.STREQ:
+			val2, _ := i.pop().(String)
+			val1, _ := i.pop().(String)
+			i.push(NewBool(val1 == val2))
+		case bytecode.STRNE:
+			val2, _ := i.pop().(String)
+			val1, _ := i.pop().(String)
+			i.push(NewBool(val1 != val2))
+		case bytecode.STRLT:
+			val2, _ := i.pop().(String)
+			val1, _ := i.pop().(String)
+			i.push(NewBool(compare(val1, val2) < 0))
+		case bytecode.STRGT:
+			val2, _ := i.pop().(String)
+			val1, _ := i.pop().(String)
+			i.push(NewBool(compare(val1, val2) > 0))
+		case bytecode.STRLE:
+			val2, _ := i.pop().(String)
+			val1, _ := i.pop().(String)
+			i.push(NewBool(compare(val1, val2) <= 0))
+		case bytecode.STRGE:
+			val2, _ := i.pop().(String)
+			val1, _ := i.pop().(String)
+			i.push(NewBool(compare(val1, val2) >= 0))
+		case bytecode.FNLOAD:
+			entry := int(binary.BigEndian.Uint32(instructions[ip+1:]))
+			arity := int(instructions[ip+5])
+			length := Int32(instructions[ip+6])
+			offset := int(binary.BigEndian.Uint32(instructions[ip+7:]))
+			size := int(binary.BigEndian.Uint32(instructions[ip+11:]))
+			flags := instructions[ip+15]
+			fn := i.realm.NewFunction(String(constants[offset:offset+size]), length, arity, flags, i.frames[i.fp-1].code, entry)
+			if callee := i.callee(); callee != nil {
+				fn.home = callee.home
+			}
+			i.push(fn)
+			ip += 15
+		case bytecode.FNCAPTURE:
+			fn := i.peek().(*Function)
+			idx := binary.BigEndian.Uint16(instructions[ip+2:])
+			frame := &i.frames[i.fp-1]
+			if instructions[ip+1] == 0 {
+				fn.upvalues = append(fn.upvalues, frame.Cell(int(idx)))
+			} else {
+				fn.upvalues = append(fn.upvalues, frame.upvalues[idx])
+			}
+			ip += 3
+		case bytecode.FNHOME:
+			fn := i.pop().(*Function)
+			fn.home = i.pop()
+			i.push(fn)
+		case bytecode.FNEXTEND:
+			fn := i.pop().(*Function)
+			if err := Extend(fn, i.pop()); err != nil {
+				return err
+			}
+			i.push(fn)
+		case bytecode.FNFIELDS:
+			initializer := i.pop().(*Function)
+			i.peek().(*Function).initializer = initializer
+		case bytecode.THISLOAD:
+			frame := &i.frames[i.fp-1]
+			var val Value = Undefined{}
+			if frame.this != nil {
+				val = frame.this
+			}
+			if IsNullish(val) && (!frame.strict || i.fp == 1) {
+				val = i.realm.global
+			}
+			i.push(val)
+		case bytecode.THISINIT:
+			frame := &i.frames[i.fp-1]
+			if frame.this != nil {
+				return referenceError("super constructor may only be called once")
+			}
+			frame.this = i.peek()
+		case bytecode.THISFIELDS:
+			frame := &i.frames[i.fp-1]
+			callee := i.callee()
+			if callee == nil || callee.initializer == nil {
+				i.push(Undefined{})
+				break
+			}
+			i.push(frame.this)
+			i.push(callee.initializer)
+
+			frame.ip = ip
+			fn, err := i.invoke(0)
+			if err != nil {
+				return err
+			}
+			if fn == nil {
+				break
+			}
+			instructions = fn.code.Instructions
+			constants = fn.code.Constants
+			ip = fn.entry - 1
+		case bytecode.ARGSLOAD:
+			frame := &i.frames[i.fp-1]
+			args := make([]Value, 0, frame.argc)
+			for idx := 1; idx <= frame.argc-len(frame.rest); idx++ {
+				val, ok := frame.Slot(idx)
+				if !ok {
+					val = Undefined{}
+				}
+				args = append(args, val)
+			}
+			i.push(i.realm.NewArguments(append(args, frame.rest...)...))
+		case bytecode.RESTLOAD:
+			i.push(i.realm.NewArray(i.frames[i.fp-1].rest...))
+		case bytecode.PRIVNEW:
+			name, _ := i.pop().(String)
+			i.push(NewPrivate(name))
+		case bytecode.ARRNEW:
+			i.push(i.realm.NewArray())
+		case bytecode.ARRPUSH:
+			val := i.pop()
+			i.peek().(*Array).Push(val)
+		case bytecode.ARRHOLE:
+			arr := i.peek().(*Array)
+			arr.SetLen(uint32(arr.Len() + 1))
+		case bytecode.ARRSPREAD:
+			vals, err := Spread(i.pop())
+			if err != nil {
+				return err
+			}
+			i.peek().(*Array).Push(vals...)
+		case bytecode.OBJNEW:
+			i.push(NewObject(i.realm.ObjectPrototype))
+		case bytecode.OBJPUT:
+			val := i.pop()
+			key := i.pop()
+			if err := Put(i.peek(), key, val); err != nil {
+				return err
+			}
+		case bytecode.OBJMETHOD:
+			fn := i.pop().(*Function)
+			key := i.pop()
+			fn.home = i.peek()
+			Define(i.peek(), key, fn, Attribute(instructions[ip+1]))
+			ip += 1
+		case bytecode.OBJGETTER:
+			fn := i.pop().(*Function)
+			key := i.pop()
+			fn.home = i.peek()
+			DefineAccessor(i.peek(), key, fn, nil, Attribute(instructions[ip+1]))
+			ip += 1
+		case bytecode.OBJSETTER:
+			fn := i.pop().(*Function)
+			key := i.pop()
+			fn.home = i.peek()
+			DefineAccessor(i.peek(), key, nil, fn, Attribute(instructions[ip+1]))
+			ip += 1
+		case bytecode.ANYADD:
+			val2 := i.pop()
+			val1 := i.pop()
+			val1, val2, err := i.primitives(val1, val2, "default")
+			if err != nil {
+				return err
+			}
+			i.push(Add(val1, val2))
+		case bytecode.ANYTOBOOL:
+			i.push(ToBool(i.pop()))
+		case bytecode.ANYTOI32:
+			val, err := i.primitive(i.pop(), "number")
+			if err != nil {
+				return err
+			}
+			i.push(ToInt32(val))
+		case bytecode.ANYTOF64:
+			val, err := i.primitive(i.pop(), "number")
+			if err != nil {
+				return err
+			}
+			i.push(ToNumber(val))
+		case bytecode.ANYTOSTR:
+			val, err := i.primitive(i.pop(), "string")
 			if err != nil {
-				f = math.NaN()
+				return err
+			}
+			i.push(ToString(val))
+		case bytecode.ANYTYPEOF:
+			i.push(TypeOf(i.pop()))
+		case bytecode.ANYNULLISH:
+			i.push(NewBool(IsNullish(i.pop())))
+		case bytecode.ANYGET:
+			key := i.pop()
+			obj := i.pop()
+			val, err := Get(i.holder(obj, key), key)
+			if err != nil {
+				return err
+			}
+			acc, ok := val.(*Accessor)
+			if !ok {
+				i.push(val)
+				break
+			}
+
+			i.frames[i.fp-1].ip = ip
+			fn, err := i.get(obj, acc)
+			if err != nil {
+				return err
+			}
+			if fn == nil {
+				break
+			}
+			instructions = fn.code.Instructions
+			constants = fn.code.Constants
+			ip = fn.entry - 1
+		case bytecode.ANYSET:
+			val := i.pop()
+			key := i.pop()
+			obj := i.pop()
+			acc, ok := accessor(i.holder(obj, key), key)
+			if !ok {
+				ok, err := Set(obj, key, val)
+				if err != nil {
+					return err
+				}
+				if ok == 0 && i.frames[i.fp-1].strict {
+					return rejected(obj, key)
+				}
+				i.push(val)
+				break
+			}
+			if acc.setter == nil && i.frames[i.fp-1].strict {
+				return fmt.Errorf("cannot set property %s of %s which has only a getter", string(ToString(key)), string(ToString(obj)))
 			}
-			i.push(Float64(f))
+
+			i.frames[i.fp-1].ip = ip
+			fn, err := i.set(obj, acc, val)
+			if err != nil {
+				return err
+			}
+			if fn == nil {
+				break
+			}
+			instructions = fn.code.Instructions
+			constants = fn.code.Constants
+			ip = fn.entry - 1
+		case bytecode.SUPERGET:
+			key := i.pop()
+			this := i.pop()
+			home, err := i.home()
+			if err != nil {
+				return err
+			}
+			val, err := Get(Prototype(home), key)
+			if err != nil {
+				return err
+			}
+			acc, ok := val.(*Accessor)
+			if !ok {
+				i.push(val)
+				break
+			}
+
+			i.frames[i.fp-1].ip = ip
+			fn, err := i.get(this, acc)
+			if err != nil {
+				return err
+			}
+			if fn == nil {
+				break
+			}
+			instructions = fn.code.Instructions
+			constants = fn.code.Constants
+			ip = fn.entry - 1
+		case bytecode.SUPERSET:
+			val := i.pop()
+			key := i.pop()
+			this := i.pop()
+			home, err := i.home()
+			if err != nil {
+				return err
+			}
+			acc, ok := accessor(Prototype(home), key)
+			if !ok {
+				ok, err := Set(this, key, val)
+				if err != nil {
+					return err
+				}
+				if ok == 0 && i.frames[i.fp-1].strict {
+					return rejected(this, key)
+				}
+				i.push(val)
+				break
+			}
+			if acc.setter == nil && i.frames[i.fp-1].strict {
+				return fmt.Errorf("cannot set property %s of %s which has only a getter", string(ToString(key)), string(ToString(this)))
+			}
+
+			i.frames[i.fp-1].ip = ip
+			fn, err := i.set(this, acc, val)
+			if err != nil {
+				return err
+			}
+			if fn == nil {
+				break
+			}
+			instructions = fn.code.Instructions
+			constants = fn.code.Constants
+			ip = fn.entry - 1
+		case bytecode.ANYDELETE:
+			key := i.pop()
+			obj := i.pop()
+			ok, err := Delete(obj, key)
+			if err != nil {
+				return err
+			}
+			if ok == 0 && i.frames[i.fp-1].strict {
+				return fmt.Errorf("cannot delete property '%s' of %s", string(ToString(key)), string(ToString(obj)))
+			}
+			i.push(ok)
+		case bytecode.ANYEQ:
+			val2 := i.pop()
+			val1 := i.pop()
+			if object(val1) != object(val2) && !IsNullish(val1) && !IsNullish(val2) {
+				var err error
+				if val1, val2, err = i.primitives(val1, val2, "default"); err != nil {
+					return err
+				}
+			}
+			i.push(NewBool(Equal(val1, val2)))
+		case bytecode.ANYNE:
+			val2 := i.pop()
+			val1 := i.pop()
+			if object(val1) != object(val2) && !IsNullish(val1) && !IsNullish(val2) {
+				var err error
+				if val1, val2, err = i.primitives(val1, val2, "default"); err != nil {
+					return err
+				}
+			}
+			i.push(NewBool(!Equal(val1, val2)))
+		case bytecode.ANYSTRICTEQ:
+			val2 := i.pop()
+			val1 := i.pop()
+			i.push(NewBool(StrictEqual(val1, val2)))
+		case bytecode.ANYSTRICTNE:
+			val2 := i.pop()
+			val1 := i.pop()
+			i.push(NewBool(!StrictEqual(val1, val2)))
+		case bytecode.ANYLT:
+			val2 := i.pop()
+			val1 := i.pop()
+			val1, val2, err := i.primitives(val1, val2, "number")
+			if err != nil {
+				return err
+			}
+			ok, _ := LessThan(val1, val2)
+			i.push(NewBool(ok))
+		case bytecode.ANYGT:
+			val2 := i.pop()
+			val1 := i.pop()
+			val1, val2, err := i.primitives(val1, val2, "number")
+			if err != nil {
+				return err
+			}
+			ok, _ := LessThan(val2, val1)
+			i.push(NewBool(ok))
+		case bytecode.ANYLE:
+			val2 := i.pop()
+			val1 := i.pop()
+			val1, val2, err := i.primitives(val1, val2, "number")
+			if err != nil {
+				return err
+			}
+			ok, defined := LessThan(val2, val1)
+			i.push(NewBool(defined && !ok))
+		case bytecode.ANYGE:
+			val2 := i.pop()
+			val1 := i.pop()
+			val1, val2, err := i.primitives(val1, val2, "number")
+			if err != nil {
+				return err
+			}
+			ok, defined := LessThan(val1, val2)
+			i.push(NewBool(defined && !ok))
+		case bytecode.ANYINSTANCEOF:
+			val2 := i.pop()
+			val1 := i.pop()
+			val, err := InstanceOf(val1, val2)
+			if err != nil {
+				return err
+			}
+			i.push(val)
 		default:
 			typ := bytecode.TypeOf(opcode)
 			if typ == nil {
@@ -185,6 +925,304 @@ func (i *Interpreter) Execute(code bytecode.Bytecode) error {
 	return nil
 }
 
+// catch finds the handler of the exception in the frames down to the bottom and reports whether it is found.
+func (i *Interpreter) catch(exception *Exception, bottom int) bool {
+	for {
+		frame := &i.frames[i.fp-1]
+		for _, h := range frame.code.Handlers {
+			if h.Start <= frame.ip && frame.ip < h.End {
+				i.sp = frame.bp
+				i.push(exception.Value())
+				frame.ip = h.Target - 1
+				return true
+			}
+		}
+		if i.fp <= bottom {
+			return false
+		}
+		i.sp = i.exit().bp
+	}
+}
+
+// invoke calls the function below the arguments on the stack with the receiver beneath it.
+func (i *Interpreter) invoke(argc int) (*Function, error) {
+	bp := i.sp - argc - 1
+	fn, ok := i.stack[bp].(*Function)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a function", string(ToString(i.stack[bp])))
+	}
+	if fn.Class() {
+		return nil, fmt.Errorf("class constructor %s cannot be invoked without 'new'", string(fn.Name()))
+	}
+	return i.enter(fn, i.stack[bp-1], argc, bp-1, nil)
+}
+
+// construct calls the function below the arguments on the stack with new.
+func (i *Interpreter) construct(argc int) (*Function, error) {
+	bp := i.sp - argc - 1
+	fn, ok := i.stack[bp].(*Function)
+	if !ok || !fn.Constructor() {
+		return nil, fmt.Errorf("%s is not a constructor", string(ToString(i.stack[bp])))
+	}
+	return i.instantiate(fn, fn, argc, bp)
+}
+
+// super calls the parent of the constructor of the current frame on behalf of the same target.
+func (i *Interpreter) super(argc int) (*Function, error) {
+	callee := i.callee()
+	if callee == nil || !callee.Derived() {
+		return nil, fmt.Errorf("'super' keyword unexpected here")
+	}
+	parent, ok := Prototype(callee).(*Function)
+	if !ok || !parent.Constructor() {
+		return nil, fmt.Errorf("super constructor %s of anonymous class is not a constructor", string(ToString(Prototype(callee))))
+	}
+	return i.instantiate(parent, i.frames[i.fp-1].target, argc, i.sp-argc)
+}
+
+// instantiate calls the constructor with this inheriting from the prototype of the target.
+func (i *Interpreter) instantiate(fn, target *Function, argc, base int) (*Function, error) {
+	var this Value
+	if !fn.Derived() {
+		proto, err := Get(target, String("prototype"))
+		if err != nil {
+			return nil, err
+		}
+		if !object(proto) {
+			proto = i.realm.ObjectPrototype
+		}
+		this = NewObject(proto)
+	}
+	return i.enter(fn, this, argc, base, target)
+}
+
+// bind backs the property of the global object named after the name with the slot of the global frame.
+func (i *Interpreter) bind(name String, idx int) {
+	if i.bound[name] {
+		return
+	}
+	i.bound[name] = true
+
+	if val, ok := i.realm.global.Get(name); ok {
+		if _, ok := val.(*Accessor); !ok {
+			if _, ok := i.frames[0].Slot(idx); !ok {
+				i.frames[0].SetSlot(idx, val)
+			}
+		}
+	}
+
+	getter := i.realm.NewNativeFunction("get "+name, 0, 0, func(_ Value, _ []Value) (Value, error) {
+		if val, ok := i.frames[0].Slot(idx); ok {
+			return val, nil
+		}
+		return Undefined{}, nil
+	})
+	setter := i.realm.NewNativeFunction("set "+name, 1, 0, func(_ Value, args []Value) (Value, error) {
+		i.frames[0].SetSlot(idx, argument(args, 0))
+		return Undefined{}, nil
+	})
+	DefineAccessor(i.realm.global, name, getter, setter, ENUMERABLE)
+}
+
+// holder returns the value to look the property up from, which is the prototype of the kind for primitives.
+func (i *Interpreter) holder(val, key Value) Value {
+	if _, ok := key.(*Private); ok || IsNullish(val) || properties(val) != nil {
+		return val
+	}
+	if _, ok := own(val, key); ok {
+		return val
+	}
+	return i.realm.Prototype(val)
+}
+
+// primitive converts objects to primitives by calling their valueOf and toString in the order the hint prefers.
+func (i *Interpreter) primitive(val Value, hint String) (Value, error) {
+	if !object(val) {
+		return val, nil
+	}
+	names := []String{"valueOf", "toString"}
+	if hint == "string" {
+		names = []String{"toString", "valueOf"}
+	}
+	for _, name := range names {
+		fn, err := i.property(val, name)
+		if err != nil {
+			return nil, err
+		}
+		if fn, ok := fn.(*Function); ok {
+			result, err := i.apply(fn, val)
+			if err != nil {
+				return nil, err
+			}
+			if !object(result) {
+				return result, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("cannot convert object to primitive value")
+}
+
+func (i *Interpreter) primitives(left, right Value, hint String) (Value, Value, error) {
+	left, err := i.primitive(left, hint)
+	if err != nil {
+		return nil, nil, err
+	}
+	right, err = i.primitive(right, hint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return left, right, nil
+}
+
+// property reads the property of the value, calling the getter of accessors.
+func (i *Interpreter) property(val, key Value) (Value, error) {
+	prop, err := Get(i.holder(val, key), key)
+	if err != nil {
+		return nil, err
+	}
+	acc, ok := prop.(*Accessor)
+	if !ok {
+		return prop, nil
+	}
+	getter, ok := acc.getter.(*Function)
+	if !ok {
+		return Undefined{}, nil
+	}
+	return i.apply(getter, val)
+}
+
+// apply calls the function to the end on top of the current frame.
+func (i *Interpreter) apply(fn *Function, this Value, args ...Value) (Value, error) {
+	if fn.Class() {
+		return nil, fmt.Errorf("class constructor %s cannot be invoked without 'new'", string(fn.Name()))
+	}
+	fp, base := i.fp, i.sp
+	for _, arg := range args {
+		i.push(arg)
+	}
+	callee, err := i.enter(fn, this, len(args), base, nil)
+	if err != nil {
+		return nil, err
+	}
+	if callee != nil {
+		i.frames[i.fp-1].ip = callee.entry - 1
+		for {
+			err := i.run(fp + 1)
+			if err == nil {
+				break
+			}
+			exception := i.raise(err)
+			if !i.catch(exception, fp+1) {
+				for i.fp > fp {
+					i.sp = i.exit().bp
+				}
+				return nil, exception
+			}
+		}
+	}
+	return i.pop(), nil
+}
+
+// get calls the getter of the accessor with the receiver.
+func (i *Interpreter) get(this Value, acc *Accessor) (*Function, error) {
+	if acc.getter == nil {
+		i.push(Undefined{})
+		return nil, nil
+	}
+	i.push(this)
+	i.push(acc.getter)
+	return i.invoke(0)
+}
+
+// set calls the setter of the accessor with the receiver, which results in the value.
+func (i *Interpreter) set(this Value, acc *Accessor, val Value) (*Function, error) {
+	if acc.setter == nil {
+		i.push(val)
+		return nil, nil
+	}
+	i.push(this)
+	i.push(acc.setter)
+	i.push(val)
+	fn, err := i.invoke(1)
+	if err != nil {
+		return nil, err
+	}
+	if fn == nil {
+		i.pop()
+		i.push(val)
+		return nil, nil
+	}
+	i.frames[i.fp-1].result = val
+	return fn, nil
+}
+
+// callee returns the function running in the current frame, which is nil at the top level.
+func (i *Interpreter) callee() *Function {
+	if i.fp <= 1 {
+		return nil
+	}
+	fn, _ := i.frames[i.fp-1].slots[0].(*Function)
+	return fn
+}
+
+// home returns the home object of the method running in the current frame.
+func (i *Interpreter) home() (Value, error) {
+	callee := i.callee()
+	if callee == nil || callee.home == nil {
+		return nil, fmt.Errorf("'super' keyword unexpected here")
+	}
+	return callee.home, nil
+}
+
+// enter starts the call of the function with the arguments on top of the stack, or runs it if native.
+func (i *Interpreter) enter(fn *Function, this Value, argc, base int, target *Function) (*Function, error) {
+	args := i.stack[i.sp-argc : i.sp]
+	if fn.native != nil {
+		val, err := fn.native(this, append([]Value(nil), args...))
+		if err != nil {
+			return nil, err
+		}
+		if target != nil && !object(val) {
+			val = this
+		}
+		if obj, ok := val.(*Object); ok && obj.errorData {
+			if _, ok := obj.Get("stack"); !ok {
+				obj.Define("stack", i.trace(obj))
+			}
+		}
+		i.sp = base
+		i.push(val)
+		return nil, nil
+	}
+	if i.fp >= maxFrames {
+		return nil, rangeError("maximum call stack size exceeded")
+	}
+
+	slots := make([]Value, fn.arity+1)
+	slots[0] = fn
+	copy(slots[1:], args)
+	var rest []Value
+	if argc > fn.arity {
+		rest = append(rest, args[fn.arity:]...)
+	}
+	i.sp = base
+
+	i.call(Frame{code: fn.code, slots: slots, upvalues: fn.upvalues, this: this, argc: argc, rest: rest, target: target, bp: base})
+	return fn, nil
+}
+
+// spread pushes the elements of the array on top of the stack in place of it, returning how many they are.
+func (i *Interpreter) spread() (int, error) {
+	args := i.pop().(*Array)
+	if args.Len() > maxArguments {
+		return 0, rangeError("too many arguments in function call")
+	}
+	for val := range args.Values() {
+		i.push(val)
+	}
+	return args.Len(), nil
+}
+
 func (i *Interpreter) call(frame Frame) {
 	if len(i.frames) <= i.fp {
 		i.frames = append(i.frames, make([]Frame, len(i.frames)+1)...)
@@ -198,8 +1236,9 @@ func (i *Interpreter) exit() Frame {
 		return Frame{}
 	}
 	i.fp--
+	frame := i.frames[i.fp]
 	i.frames[i.fp] = Frame{}
-	return i.frames[i.fp]
+	return frame
 }
 
 func (i *Interpreter) push(val Value) {
@@ -210,6 +1249,13 @@ func (i *Interpreter) push(val Value) {
 	i.sp++
 }
 
+func (i *Interpreter) peek() Value {
+	if i.sp == 0 {
+		return nil
+	}
+	return i.stack[i.sp-1]
+}
+
 func (i *Interpreter) pop() Value {
 	if i.sp == 0 {
 		return nil
@@ -217,3 +1263,16 @@ func (i *Interpreter) pop() Value {
 	i.sp--
 	return i.stack[i.sp]
 }
+
+func rejected(val, key Value) error {
+	if _, ok := own(val, key); ok {
+		return fmt.Errorf("cannot assign to read only property '%s' of %s", string(ToString(key)), string(ToString(val)))
+	}
+	if properties(val) == nil {
+		return fmt.Errorf("cannot create property '%s' on %s '%s'", string(ToString(key)), string(TypeOf(val)), string(ToString(val)))
+	}
+	if !extensible(val, key) {
+		return fmt.Errorf("cannot add property %s, object is not extensible", string(ToString(key)))
+	}
+	return fmt.Errorf("cannot assign to read only property '%s' of %s", string(ToString(key)), string(ToString(val)))
+}
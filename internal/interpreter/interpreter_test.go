@@ -9,169 +9,918 @@ import (
 )
 
 func TestInterpreter_Execute(t *testing.T) {
+	realm := NewRealm()
+
 	tests := []struct {
 		instructions []bytecode.Instruction
 		literals     []string
+		handlers     []bytecode.Handler
 		stack        []Value
 	}{
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.NOP),
+				bytecode.New(bytecode.NOP),
+			},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.POP),
+			},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 1),
+			},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 1),
+				bytecode.New(bytecode.SLTLOAD, 1),
+			},
+			stack: []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTCHECK, 0, 0, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+			},
+			literals: []string{"a"},
+			stack:    []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.SLTCLEAR, 0),
+				bytecode.New(bytecode.SLTLOAD, 0),
+			},
+			stack: []Value{Undefined{}},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.GLBSTORE, 0),
+				bytecode.New(bytecode.GLBLOAD, 0),
+			},
+			stack: []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 9),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.FNLOAD, 5, 1, 1, 0, 1, 1),
+				bytecode.New(bytecode.I32LOAD, 7),
+				bytecode.New(bytecode.CALL, 1),
+			},
+			literals: []string{"f"},
+			stack:    []Value{Int32(7)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 9),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.FNLOAD, 5, 1, 1, 0, 1, 1),
+				bytecode.New(bytecode.CALL, 0),
+			},
+			literals: []string{"f"},
+			stack:    []Value{Undefined{}},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 9),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 5, 2, 2, 0, 1, 1),
+				bytecode.New(bytecode.STRLOAD, 2, 6),
+				bytecode.New(bytecode.ANYGET),
+			},
+			literals: []string{"f", "length"},
+			stack:    []Value{Int32(2)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 9),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 5, 0, 0, 0, 1, 1),
+				bytecode.New(bytecode.ANYTYPEOF),
+			},
+			literals: []string{"f"},
+			stack:    []Value{String("function")},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.THISLOAD),
+			},
+			stack: []Value{realm.global},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 7),
+				bytecode.New(bytecode.THISLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.I32LOAD, 3),
+				bytecode.New(bytecode.FNLOAD, 5, 0, 0, 0, 1, 1),
+				bytecode.New(bytecode.CALL, 0),
+			},
+			literals: []string{"f"},
+			stack:    []Value{Int32(3)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 24),
+				bytecode.New(bytecode.THISLOAD),
+				bytecode.New(bytecode.STRLOAD, 2, 1),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ANYSET),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 5, 0, 0, 0, 1, 1),
+				bytecode.New(bytecode.NEW, 0),
+				bytecode.New(bytecode.STRLOAD, 2, 1),
+				bytecode.New(bytecode.ANYGET),
+			},
+			literals: []string{"f", "x"},
+			stack:    []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.OBJNEW),
+				bytecode.New(bytecode.BUILTINLOAD, 0, 6),
+				bytecode.New(bytecode.ANYINSTANCEOF),
+			},
+			literals: []string{"Object"},
+			stack:    []Value{Bool(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.OBJNEW),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.JMP, 21),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 15, 0, 0, 0, 1, 0),
+				bytecode.New(bytecode.OBJGETTER, 6),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.ANYGET),
+			},
+			literals: []string{"x"},
+			stack:    []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.OBJNEW),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.JMP, 21),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 15, 0, 0, 0, 1, 0),
+				bytecode.New(bytecode.OBJGETTER, 6),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.ANYSET),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.ANYGET),
+			},
+			literals: []string{"x"},
+			stack:    []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 2),
+				bytecode.New(bytecode.PRIVNEW),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.OBJNEW),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.OBJPUT),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.ANYGET),
+			},
+			literals: []string{"#x"},
+			stack:    []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 7),
+				bytecode.New(bytecode.ARGSLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.FNLOAD, 5, 1, 1, 0, 1, 1),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.CALL, 2),
+			},
+			literals: []string{"f"},
+			stack:    []Value{realm.NewArguments(Int32(1), Int32(2))},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 7),
+				bytecode.New(bytecode.RESTLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.FNLOAD, 5, 0, 0, 0, 1, 1),
+				bytecode.New(bytecode.ARRNEW),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ARRPUSH),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.ARRPUSH),
+				bytecode.New(bytecode.APPLY),
+			},
+			literals: []string{"f"},
+			stack:    []Value{realm.NewArray(Int32(1), Int32(2))},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.ARRNEW),
+				bytecode.New(bytecode.ARRHOLE),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ARRPUSH),
+			},
+			stack: []Value{realm.NewArray(nil, Int32(1))},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.ARRNEW),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ARRPUSH),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(3)),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.ANYSET),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.STRLOAD, 0, 6),
+				bytecode.New(bytecode.ANYGET),
+			},
+			literals: []string{"length"},
+			stack:    []Value{Int32(4)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.ARRNEW),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ARRPUSH),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.ARRPUSH),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.STRLOAD, 0, 6),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ANYSET),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.STRLOAD, 7, 1),
+				bytecode.New(bytecode.ANYGET),
+			},
+			literals: []string{"length", "1"},
+			stack:    []Value{Undefined{}},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.ARRNEW),
+				bytecode.New(bytecode.STRLOAD, 0, 2),
+				bytecode.New(bytecode.ARRSPREAD),
+			},
+			literals: []string{"ab"},
+			stack:    []Value{realm.NewArray(String("a"), String("b"))},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.CELLSTORE, 0),
+				bytecode.New(bytecode.CELLCHECK, 0, 0, 1),
+				bytecode.New(bytecode.CELLLOAD, 0),
+			},
+			literals: []string{"a"},
+			stack:    []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.CELLSTORE, 0),
+				bytecode.New(bytecode.JMP, 17),
+				bytecode.New(bytecode.UPVLOAD, 0),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.FNLOAD, 13, 0, 0, 0, 1, 1),
+				bytecode.New(bytecode.FNCAPTURE, 0, 0),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.CELLSTORE, 0),
+				bytecode.New(bytecode.CALL, 0),
+			},
+			literals: []string{"f"},
+			stack:    []Value{Int32(2)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.CELLSTORE, 0),
+				bytecode.New(bytecode.JMP, 23),
+				bytecode.New(bytecode.I32LOAD, 3),
+				bytecode.New(bytecode.UPVSTORE, 0),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.FNLOAD, 13, 0, 0, 0, 1, 1),
+				bytecode.New(bytecode.FNCAPTURE, 0, 0),
+				bytecode.New(bytecode.CALL, 0),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.CELLLOAD, 0),
+			},
+			literals: []string{"f"},
+			stack:    []Value{Int32(3)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.CELLSTORE, 0),
+				bytecode.New(bytecode.JMP, 17),
+				bytecode.New(bytecode.UPVLOAD, 0),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.FNLOAD, 13, 0, 0, 0, 1, 1),
+				bytecode.New(bytecode.FNCAPTURE, 0, 0),
+				bytecode.New(bytecode.SLTBOX, 0),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.CELLSTORE, 0),
+				bytecode.New(bytecode.CALL, 0),
+			},
+			literals: []string{"f"},
+			stack:    []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.DUP),
+			},
+			stack: []Value{Int32(1), Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.DUP2),
+			},
+			stack: []Value{Int32(2), Int32(1), Int32(2), Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32LOAD, 3),
+				bytecode.New(bytecode.INSERT, 2),
+			},
+			stack: []Value{Int32(3), Int32(2), Int32(1), Int32(3)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.JMP, 10),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+			},
+			stack: []Value{Int32(2)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.JMPT, 12),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+			},
+			stack: []Value{Int32(2)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.JMPF, 12),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+			},
+			stack: []Value{Int32(2), Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.SLTSTORE, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.I32LOAD, 10),
+				bytecode.New(bytecode.I32LT),
+				bytecode.New(bytecode.JMPF, 57),
+				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.I32ADD),
+				bytecode.New(bytecode.SLTSTORE, 1),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32ADD),
+				bytecode.New(bytecode.SLTSTORE, 0),
+				bytecode.New(bytecode.JMP, 16),
+				bytecode.New(bytecode.SLTLOAD, 1),
+			},
+			stack: []Value{Int32(45)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32SWITCH, 0, 2),
+				bytecode.New(bytecode.JMP, 39),
+				bytecode.New(bytecode.JMP, 34),
+				bytecode.New(bytecode.JMP, 29),
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+			},
+			stack: []Value{Int32(2), Int32(1), Int32(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 5),
+				bytecode.New(bytecode.I32SWITCH, 0, 2),
+				bytecode.New(bytecode.JMP, 39),
+				bytecode.New(bytecode.JMP, 34),
+				bytecode.New(bytecode.JMP, 29),
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+			},
+			stack: []Value{Int32(2)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.UNDEFLOAD),
+			},
+			stack: []Value{Undefined{}},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 5),
+				bytecode.New(bytecode.I32LOAD, 3),
+				bytecode.New(bytecode.I32AND),
+			},
+			stack: []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 5),
+				bytecode.New(bytecode.I32LOAD, 3),
+				bytecode.New(bytecode.I32OR),
+			},
+			stack: []Value{Int32(7)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 5),
+				bytecode.New(bytecode.I32LOAD, 3),
+				bytecode.New(bytecode.I32XOR),
+			},
+			stack: []Value{Int32(6)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 5),
+				bytecode.New(bytecode.I32NOT),
+			},
+			stack: []Value{Int32(-6)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 33),
+				bytecode.New(bytecode.I32SHL),
+			},
+			stack: []Value{Int32(2)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 0xFFFFFFF8),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32SHR),
+			},
+			stack: []Value{Int32(-4)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 0xFFFFFFF8),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32USHR),
+			},
+			stack: []Value{Float64(2147483644)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(4294967297.5)),
+				bytecode.New(bytecode.F64TOI32),
+			},
+			stack: []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(-2147483649)),
+				bytecode.New(bytecode.F64TOI32),
+			},
+			stack: []Value{Int32(2147483647)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.Inf(1))),
+				bytecode.New(bytecode.ANYTOI32),
+			},
+			stack: []Value{Int32(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.UNDEFTOF64),
+			},
+			//stack: []Value{Float64(math.NaN())},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.UNDEFTOSTR),
+			},
+			stack: []Value{String("undefined")},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.UNDEFTOBOOL),
+			},
+			stack: []Value{Bool(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.NULLLOAD),
+			},
+			stack: []Value{Null{}},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.NULLTOI32),
+			},
+			stack: []Value{Int32(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.NULLTOSTR),
+			},
+			stack: []Value{String("null")},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.NULLTOBOOL),
+			},
+			stack: []Value{Bool(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+			},
+			stack: []Value{Bool(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.BOOLTOI32),
+			},
+			stack: []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.BOOLTOSTR),
+			},
+			stack: []Value{String("true")},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.BOOLNOT),
+			},
+			stack: []Value{Bool(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+			},
+			stack: []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32ADD),
+			},
+			stack: []Value{Int32(3)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, math.MaxInt32),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32ADD),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32GT),
+			},
+			stack: []Value{NewBool(true)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32SUB),
+			},
+			stack: []Value{Int32(-1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32MUL),
+			},
+			stack: []Value{Int32(2)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 6),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32DIV),
+			},
+			stack: []Value{Int32(3)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 7),
+				bytecode.New(bytecode.I32LOAD, 3),
+				bytecode.New(bytecode.I32MOD),
+			},
+			stack: []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 5),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32DIV),
 			},
+			stack: []Value{Int32(5)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32TOBOOL),
 			},
+			stack: []Value{Bool(1)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.I32LOAD, 1),
-				bytecode.New(bytecode.SLTSTORE, 1),
+				bytecode.New(bytecode.I32LOAD, 5),
+				bytecode.New(bytecode.I32TOF64),
 			},
+			stack: []Value{Float64(5)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.I32LOAD, 1),
-				bytecode.New(bytecode.SLTSTORE, 1),
-				bytecode.New(bytecode.SLTLOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 42),
+				bytecode.New(bytecode.I32TOSTR),
 			},
-			stack: []Value{Int32(1)},
+			stack: []Value{String("42")},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.I32LOAD, uint64(0xFFFFFFFFFFFFFFFF)),
+				bytecode.New(bytecode.I32TOBOOL),
 			},
-			stack: []Value{Undefined{}},
+			stack: []Value{Bool(1)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.UNDEFLOAD),
-				bytecode.New(bytecode.UNDEFTOF64),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.NaN())),
+				bytecode.New(bytecode.F64TOBOOL),
 			},
-			//stack: []Value{Float64(math.NaN())},
+			stack: []Value{Bool(0)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.UNDEFLOAD),
-				bytecode.New(bytecode.UNDEFTOSTR),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(0.5)),
+				bytecode.New(bytecode.F64TOBOOL),
 			},
-			stack: []Value{String("undefined")},
+			stack: []Value{Bool(1)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.STRLOAD, 0, 0),
+				bytecode.New(bytecode.STRTOBOOL),
 			},
-			stack: []Value{Null{}},
+			literals: []string{""},
+			stack:    []Value{Bool(0)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.NULLLOAD),
-				bytecode.New(bytecode.NULLTOI32),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.Copysign(0, -1))),
+				bytecode.New(bytecode.ANYTOBOOL),
 			},
-			stack: []Value{Int32(0)},
+			stack: []Value{Bool(0)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.NULLLOAD),
-				bytecode.New(bytecode.NULLTOSTR),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.ANYTOBOOL),
 			},
-			stack: []Value{String("null")},
+			literals: []string{"0"},
+			stack:    []Value{Bool(1)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ANYADD),
 			},
-			stack: []Value{Bool(1)},
+			literals: []string{"a"},
+			stack:    []Value{String("a1")},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.BOOLLOAD, 1),
-				bytecode.New(bytecode.BOOLTOI32),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(0.5)),
+				bytecode.New(bytecode.ANYADD),
 			},
-			stack: []Value{Int32(1)},
+			stack: []Value{Float64(1.5)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 3),
+				bytecode.New(bytecode.ANYTOF64),
+			},
+			literals: []string{"0x1"},
+			stack:    []Value{Float64(1)},
 		},
 		{
 			instructions: []bytecode.Instruction{
 				bytecode.New(bytecode.BOOLLOAD, 1),
-				bytecode.New(bytecode.BOOLTOSTR),
+				bytecode.New(bytecode.ANYTOSTR),
 			},
 			stack: []Value{String("true")},
 		},
 		{
 			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.ANYNULLISH),
+			},
+			stack: []Value{Bool(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.ANYNULLISH),
+			},
+			stack: []Value{Bool(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 3),
+				bytecode.New(bytecode.STRLOAD, 4, 6),
+				bytecode.New(bytecode.ANYGET),
+			},
+			literals: []string{"foo", "length"},
+			stack:    []Value{Int32(3)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 3),
 				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ANYGET),
 			},
-			stack: []Value{Int32(1)},
+			literals: []string{"foo"},
+			stack:    []Value{String("o")},
 		},
 		{
 			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.OBJNEW),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
 				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.OBJPUT),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.ANYGET),
+			},
+			literals: []string{"a"},
+			stack:    []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.OBJNEW),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
 				bytecode.New(bytecode.I32LOAD, 2),
-				bytecode.New(bytecode.I32ADD),
+				bytecode.New(bytecode.ANYSET),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.ANYGET),
 			},
-			stack: []Value{Int32(3)},
+			literals: []string{"a"},
+			stack:    []Value{Int32(2)},
 		},
 		{
 			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.OBJNEW),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
 				bytecode.New(bytecode.I32LOAD, 1),
-				bytecode.New(bytecode.I32LOAD, 2),
-				bytecode.New(bytecode.I32SUB),
+				bytecode.New(bytecode.ANYSET),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.ANYDELETE),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.ANYGET),
 			},
-			stack: []Value{Int32(-1)},
+			literals: []string{"a"},
+			stack:    []Value{Undefined{}},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.ANYTYPEOF),
+			},
+			stack: []Value{String("object")},
 		},
 		{
 			instructions: []bytecode.Instruction{
 				bytecode.New(bytecode.I32LOAD, 1),
-				bytecode.New(bytecode.I32LOAD, 2),
-				bytecode.New(bytecode.I32MUL),
+				bytecode.New(bytecode.ANYTYPEOF),
 			},
-			stack: []Value{Int32(2)},
+			stack: []Value{String("number")},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.I32LOAD, 6),
-				bytecode.New(bytecode.I32LOAD, 2),
-				bytecode.New(bytecode.I32DIV),
+				bytecode.New(bytecode.SLTLOAD, 0),
+				bytecode.New(bytecode.ANYTYPEOF),
 			},
-			stack: []Value{Int32(3)},
+			stack: []Value{String("undefined")},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.I32LOAD, 7),
-				bytecode.New(bytecode.I32LOAD, 3),
-				bytecode.New(bytecode.I32MOD),
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.ANYEQ),
 			},
-			stack: []Value{Int32(1)},
+			stack: []Value{Bool(1)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.I32LOAD, 5),
-				bytecode.New(bytecode.I32LOAD, 1),
-				bytecode.New(bytecode.I32DIV),
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.ANYSTRICTEQ),
 			},
-			stack: []Value{Int32(5)},
+			stack: []Value{Bool(0)},
 		},
 		{
 			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 1),
 				bytecode.New(bytecode.I32LOAD, 1),
-				bytecode.New(bytecode.I32TOBOOL),
+				bytecode.New(bytecode.ANYNE),
 			},
-			stack: []Value{Bool(1)},
+			literals: []string{"1"},
+			stack:    []Value{Bool(0)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.I32LOAD, 5),
-				bytecode.New(bytecode.I32TOF64),
+				bytecode.New(bytecode.STRLOAD, 0, 2),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.ANYLT),
 			},
-			stack: []Value{Float64(5)},
+			literals: []string{"10"},
+			stack:    []Value{Bool(0)},
 		},
 		{
 			instructions: []bytecode.Instruction{
-				bytecode.New(bytecode.I32LOAD, 42),
-				bytecode.New(bytecode.I32TOSTR),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.ANYLE),
 			},
-			stack: []Value{String("42")},
+			stack: []Value{Bool(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.ANYGE),
+			},
+			stack: []Value{Bool(1)},
 		},
 		{
 			instructions: []bytecode.Instruction{
@@ -219,6 +968,86 @@ func TestInterpreter_Execute(t *testing.T) {
 			},
 			stack: []Value{Float64(1)},
 		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(2)),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(10)),
+				bytecode.New(bytecode.F64POW),
+			},
+			stack: []Value{Float64(1024)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.NaN())),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(0)),
+				bytecode.New(bytecode.F64POW),
+			},
+			stack: []Value{Float64(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1)),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.NaN())),
+				bytecode.New(bytecode.F64POW),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.F64NE),
+			},
+			stack: []Value{Bool(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(-1)),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.Inf(1))),
+				bytecode.New(bytecode.F64POW),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.F64NE),
+			},
+			stack: []Value{Bool(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1)),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.Inf(-1))),
+				bytecode.New(bytecode.F64POW),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.F64NE),
+			},
+			stack: []Value{Bool(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(0)),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(-1)),
+				bytecode.New(bytecode.F64POW),
+			},
+			stack: []Value{Float64(math.Inf(1))},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.Copysign(0, -1))),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(-1)),
+				bytecode.New(bytecode.F64POW),
+			},
+			stack: []Value{Float64(math.Inf(-1))},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.Copysign(0, -1))),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(-2)),
+				bytecode.New(bytecode.F64POW),
+			},
+			stack: []Value{Float64(math.Inf(1))},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(-8)),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1.0/3)),
+				bytecode.New(bytecode.F64POW),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.F64NE),
+			},
+			stack: []Value{Bool(1)},
+		},
 		{
 			instructions: []bytecode.Instruction{
 				bytecode.New(bytecode.F64LOAD, math.Float64bits(3.7)),
@@ -265,6 +1094,123 @@ func TestInterpreter_Execute(t *testing.T) {
 			literals: []string{"1"},
 			stack:    []Value{Float64(1)},
 		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 4),
+				bytecode.New(bytecode.STRTOF64),
+			},
+			literals: []string{"0x1F"},
+			stack:    []Value{Float64(31)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32LT),
+			},
+			stack: []Value{Bool(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32GE),
+			},
+			stack: []Value{Bool(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.NaN())),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.NaN())),
+				bytecode.New(bytecode.F64EQ),
+			},
+			stack: []Value{Bool(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.NaN())),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.NaN())),
+				bytecode.New(bytecode.F64NE),
+			},
+			stack: []Value{Bool(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.NaN())),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1)),
+				bytecode.New(bytecode.F64LE),
+			},
+			stack: []Value{Bool(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.STRLOAD, 2, 1),
+				bytecode.New(bytecode.STRLT),
+			},
+			literals: []string{"a", "b"},
+			stack:    []Value{Bool(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 3),
+				bytecode.New(bytecode.STRLOAD, 4, 4),
+				bytecode.New(bytecode.STRLT),
+			},
+			literals: []string{"\uffff", "😀"},
+			stack:    []Value{Bool(0)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.STRLOAD, 0, 1),
+				bytecode.New(bytecode.STREQ),
+			},
+			literals: []string{"a"},
+			stack:    []Value{Bool(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.THROW),
+				bytecode.New(bytecode.I32LOAD, 2),
+			},
+			handlers: []bytecode.Handler{{Start: 0, End: 6, Target: 11}},
+			stack:    []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.GLBSTORE, 0),
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
+				bytecode.New(bytecode.GLBLOAD, 0),
+			},
+			literals: []string{"a"},
+			stack:    []Value{Int32(1)},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.GLBCHECK, 0, 0, 1),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.STRLOAD, 2, 7),
+				bytecode.New(bytecode.ANYGET),
+			},
+			literals: []string{"a", "message"},
+			handlers: []bytecode.Handler{{Start: 0, End: 11, Target: 16}},
+			stack:    []Value{String("a is not defined")},
+		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.SLTCLEAR, 0),
+				bytecode.New(bytecode.GLBHOLE, 0, 0, 1),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.STRLOAD, 2, 7),
+				bytecode.New(bytecode.ANYGET),
+			},
+			literals: []string{"a", "message"},
+			handlers: []bytecode.Handler{{Start: 3, End: 14, Target: 19}},
+			stack:    []Value{String("cannot access 'a' before initialization")},
+		},
 	}
 
 	for _, tt := range tests {
@@ -273,9 +1219,11 @@ func TestInterpreter_Execute(t *testing.T) {
 		for _, c := range tt.literals {
 			code.Store([]byte(c + "\x00"))
 		}
+		code.Handlers = tt.handlers
 
 		t.Run(code.String(), func(t *testing.T) {
 			interpreter := New()
+			interpreter.realm = realm
 
 			err := interpreter.Execute(code)
 			assert.NoError(t, err)
@@ -495,6 +1443,14 @@ func BenchmarkInterpreter_Execute(b *testing.B) {
 				bytecode.New(bytecode.POP),
 			},
 		},
+		{
+			instructions: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(2)),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(10)),
+				bytecode.New(bytecode.F64POW),
+				bytecode.New(bytecode.POP),
+			},
+		},
 		{
 			instructions: []bytecode.Instruction{
 				bytecode.New(bytecode.F64LOAD, math.Float64bits(3.7)),
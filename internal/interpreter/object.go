@@ -0,0 +1,221 @@
+package interpreter
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// Object is a collection of properties, which keeps the order they are created in.
+type Object struct {
+	proto        Value
+	keys         []String
+	values       map[String]Value
+	attributes   map[String]Attribute
+	inextensible bool
+	privates     map[*Private]Value
+	errorData    bool
+}
+
+// Attribute is a set of flags telling how a property may be used.
+type Attribute byte
+
+const (
+	WRITABLE Attribute = 1 << iota
+	ENUMERABLE
+	CONFIGURABLE
+)
+
+// Accessor is a property computed by its getter and written by its setter, either of which may be missing.
+type Accessor struct {
+	getter Value
+	setter Value
+}
+
+// Private is the name of a private member of a class.
+type Private struct {
+	name String
+}
+
+func NewObject(proto Value) *Object {
+	return &Object{proto: proto, values: make(map[String]Value)}
+}
+
+func (o *Object) Prototype() Value {
+	return o.proto
+}
+
+func (o *Object) SetPrototype(proto Value) {
+	o.proto = proto
+}
+
+func (o *Object) Get(key String) (Value, bool) {
+	val, ok := o.values[key]
+	return val, ok
+}
+
+func (o *Object) Set(key String, val Value) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = val
+}
+
+// Define sets the property hidden from Keys, as built-in ones are.
+func (o *Object) Define(key String, val Value) {
+	o.Set(key, val)
+	o.SetAttributes(key, WRITABLE|CONFIGURABLE)
+}
+
+func (o *Object) Attributes(key String) (Attribute, bool) {
+	attrs, ok := o.attributes[key]
+	return attrs, ok
+}
+
+func (o *Object) SetAttributes(key String, attrs Attribute) {
+	if attrs == WRITABLE|ENUMERABLE|CONFIGURABLE {
+		delete(o.attributes, key)
+		return
+	}
+	if o.attributes == nil {
+		o.attributes = make(map[String]Attribute)
+	}
+	o.attributes[key] = attrs
+}
+
+func (o *Object) Extensible() bool {
+	return !o.inextensible
+}
+
+func (o *Object) PreventExtensions() {
+	o.inextensible = true
+}
+
+func (o *Object) Private(key *Private) (Value, bool) {
+	val, ok := o.privates[key]
+	return val, ok
+}
+
+func (o *Object) SetPrivate(key *Private, val Value) {
+	if o.privates == nil {
+		o.privates = make(map[*Private]Value)
+	}
+	o.privates[key] = val
+}
+
+func (o *Object) Delete(key String) bool {
+	delete(o.attributes, key)
+	if _, ok := o.values[key]; !ok {
+		return true
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// Keys returns the keys of the enumerable properties, with array indices first.
+func (o *Object) Keys() []String {
+	return o.list(ENUMERABLE)
+}
+
+func (o *Object) OwnKeys() []String {
+	return o.list(0)
+}
+
+func (o *Object) list(attrs Attribute) []String {
+	var indices, names []String
+	for _, key := range o.keys {
+		if a, ok := o.attributes[key]; ok && a&attrs != attrs {
+			continue
+		}
+		if _, ok := index(key); ok {
+			indices = append(indices, key)
+		} else {
+			names = append(names, key)
+		}
+	}
+	slices.SortFunc(indices, func(a, b String) int {
+		i, _ := index(a)
+		j, _ := index(b)
+		return cmp.Compare(i, j)
+	})
+	return append(indices, names...)
+}
+
+func (o *Object) Type() Type {
+	return OBJECT
+}
+
+func (o *Object) Interface() any {
+	return o
+}
+
+func (o *Object) String() string {
+	return inspect(o, nil)
+}
+
+// index converts the key to an array index if it is the canonical form of one.
+func index(key String) (uint32, bool) {
+	idx, err := strconv.ParseUint(string(key), 10, 32)
+	if err != nil || idx == math.MaxUint32 || strconv.FormatUint(idx, 10) != string(key) {
+		return 0, false
+	}
+	return uint32(idx), true
+}
+
+func NewAccessor(getter, setter Value) *Accessor {
+	return &Accessor{getter: getter, setter: setter}
+}
+
+func (a *Accessor) Getter() Value {
+	return a.getter
+}
+
+func (a *Accessor) Setter() Value {
+	return a.setter
+}
+
+func (a *Accessor) Type() Type {
+	return OBJECT
+}
+
+func (a *Accessor) Interface() any {
+	return a
+}
+
+func (a *Accessor) String() string {
+	switch {
+	case a.getter != nil && a.setter != nil:
+		return "[Getter/Setter]"
+	case a.getter != nil:
+		return "[Getter]"
+	default:
+		return "[Setter]"
+	}
+}
+
+func NewPrivate(name String) *Private {
+	return &Private{name: name}
+}
+
+func (p *Private) Name() String {
+	return p.name
+}
+
+func (p *Private) Type() Type {
+	return OBJECT
+}
+
+func (p *Private) Interface() any {
+	return p
+}
+
+func (p *Private) String() string {
+	return string(p.name)
+}
@@ -0,0 +1,896 @@
+package interpreter
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"unicode/utf16"
+)
+
+// Add applies the addition operator of ECMAScript, concatenating when either operand is a string.
+func Add(left, right Value) Value {
+	left, right = ToPrimitive(left), ToPrimitive(right)
+
+	_, ok1 := left.(String)
+	_, ok2 := right.(String)
+	if ok1 || ok2 {
+		return ToString(left) + ToString(right)
+	}
+
+	val1, ok1 := left.(Int32)
+	val2, ok2 := right.(Int32)
+	if ok1 && ok2 {
+		sum := int64(val1) + int64(val2)
+		if sum == int64(int32(sum)) {
+			return Int32(sum)
+		}
+	}
+	return ToNumber(left) + ToNumber(right)
+}
+
+// addInt32 adds numbers typed as integers, which widen to floats once a result overflows.
+func addInt32(left, right Value) Value {
+	val1, ok1 := left.(Int32)
+	val2, ok2 := right.(Int32)
+	if ok1 && ok2 {
+		if sum := int64(val1) + int64(val2); sum == int64(int32(sum)) {
+			return Int32(sum)
+		}
+	}
+	return ToNumber(left) + ToNumber(right)
+}
+
+// subInt32 subtracts numbers typed as integers, which widen to floats once a result overflows.
+func subInt32(left, right Value) Value {
+	val1, ok1 := left.(Int32)
+	val2, ok2 := right.(Int32)
+	if ok1 && ok2 {
+		if diff := int64(val1) - int64(val2); diff == int64(int32(diff)) {
+			return Int32(diff)
+		}
+	}
+	return ToNumber(left) - ToNumber(right)
+}
+
+// mulInt32 multiplies numbers typed as integers, which widen to floats once a result overflows.
+func mulInt32(left, right Value) Value {
+	val1, ok1 := left.(Int32)
+	val2, ok2 := right.(Int32)
+	if ok1 && ok2 {
+		if prod := int64(val1) * int64(val2); prod == int64(int32(prod)) {
+			return Int32(prod)
+		}
+	}
+	return ToNumber(left) * ToNumber(right)
+}
+
+// Pow applies the exponentiation operator of ECMAScript, which differs from math.Pow when the base is ±1.
+func Pow(base, exponent Float64) Float64 {
+	if math.IsNaN(float64(exponent)) {
+		return Float64(math.NaN())
+	}
+	if math.Abs(float64(base)) == 1 && math.IsInf(float64(exponent), 0) {
+		return Float64(math.NaN())
+	}
+	return Float64(math.Pow(float64(base), float64(exponent)))
+}
+
+// TypeOf applies the typeof operator of ECMAScript.
+func TypeOf(val Value) String {
+	if val == nil {
+		return "undefined"
+	}
+
+	switch val.Type() {
+	case NULL, OBJECT:
+		return "object"
+	case BOOL:
+		return "boolean"
+	case INT32, FLOAT64:
+		return "number"
+	case STRING:
+		return "string"
+	case FUNCTION:
+		return "function"
+	default:
+		return "undefined"
+	}
+}
+
+// IsNullish reports whether the value is null or undefined.
+func IsNullish(val Value) bool {
+	switch val.(type) {
+	case nil, Undefined, Null:
+		return true
+	default:
+		return false
+	}
+}
+
+// Spread lists the elements of an iterable value.
+func Spread(val Value) ([]Value, error) {
+	switch val := val.(type) {
+	case *Array:
+		if val.Len() > maxSpread {
+			return nil, rangeError("invalid array length")
+		}
+		return slices.Collect(val.Values()), nil
+	case *Arguments:
+		return append([]Value(nil), val.values...), nil
+	case String:
+		var vals []Value
+		for _, r := range string(val) {
+			vals = append(vals, String(r))
+		}
+		return vals, nil
+	default:
+		return nil, fmt.Errorf("%s is not iterable", string(ToString(val)))
+	}
+}
+
+// Get reads a property of the value along its prototype chain, returning accessors as they are.
+func Get(val, key Value) (Value, error) {
+	if IsNullish(val) {
+		return nil, fmt.Errorf("cannot read properties of %s (reading '%s')", string(ToString(val)), string(ToString(key)))
+	}
+
+	if key, ok := key.(*Private); ok {
+		prop, ok := private(val, key)
+		if !ok {
+			return nil, fmt.Errorf("cannot read private member %s from an object whose class did not declare it", string(key.Name()))
+		}
+		return prop, nil
+	}
+
+	for obj := val; !IsNullish(obj); obj = Prototype(obj) {
+		if prop, ok := own(obj, key); ok {
+			return prop, nil
+		}
+	}
+	return Undefined{}, nil
+}
+
+// Set writes a property of the value, reporting whether it is written.
+func Set(val, key, prop Value) (Bool, error) {
+	if IsNullish(val) {
+		return 0, fmt.Errorf("cannot set properties of %s (setting '%s')", string(ToString(val)), string(ToString(key)))
+	}
+
+	if key, ok := key.(*Private); ok {
+		obj := properties(val)
+		if obj == nil {
+			return 0, fmt.Errorf("cannot write private member %s to an object whose class did not declare it", string(key.Name()))
+		}
+		if _, ok := obj.Private(key); ok {
+			obj.SetPrivate(key, prop)
+			return Bool(1), nil
+		}
+		if _, ok := private(val, key); ok {
+			return 0, fmt.Errorf("private method %s is not writable", string(key.Name()))
+		}
+		return 0, fmt.Errorf("cannot write private member %s to an object whose class did not declare it", string(key.Name()))
+	}
+
+	if !writable(val, key) {
+		return Bool(0), nil
+	}
+
+	switch val := val.(type) {
+	case *Object:
+		val.Set(ToPropertyKey(key), prop)
+	case *Array:
+		if idx, ok := arrayIndex(key); ok {
+			val.SetIndex(idx, prop)
+			return Bool(1), nil
+		}
+		key := ToPropertyKey(key)
+		if key != "length" {
+			val.Object().Set(key, prop)
+			return Bool(1), nil
+		}
+		n, ok := arrayLength(prop)
+		if !ok {
+			return 0, rangeError("invalid array length")
+		}
+		return NewBool(truncate(val, n)), nil
+	case *Arguments:
+		if idx, ok := arrayIndex(key); ok && int64(idx) < int64(val.Len()) {
+			val.SetAt(int(idx), prop)
+			return Bool(1), nil
+		}
+		val.Object().Set(ToPropertyKey(key), prop)
+	case *Function:
+		val.Object().Set(ToPropertyKey(key), prop)
+	default:
+	}
+	return Bool(1), nil
+}
+
+// Put creates or replaces an own property of the value as a literal or a field does.
+func Put(val, key, prop Value) error {
+	switch key := key.(type) {
+	case *Private:
+		obj := properties(val)
+		if obj == nil {
+			return fmt.Errorf("cannot define private member %s on %s", string(key.Name()), string(ToString(val)))
+		}
+		if _, ok := obj.Private(key); ok {
+			return fmt.Errorf("cannot initialize %s twice on the same object", string(key.Name()))
+		}
+		obj.SetPrivate(key, prop)
+		return nil
+	default:
+	}
+	if obj, ok := val.(*Object); ok && obj.Extensible() {
+		k := ToPropertyKey(key)
+		if _, ok := obj.Attributes(k); !ok {
+			obj.Set(k, prop)
+			return nil
+		}
+	}
+	if properties(val) == nil {
+		_, err := Set(val, key, prop)
+		return err
+	}
+	return DefineProperty(val, key, Descriptor{Value: prop, Attributes: WRITABLE | ENUMERABLE | CONFIGURABLE, Mask: WRITABLE | ENUMERABLE | CONFIGURABLE})
+}
+
+// Define creates or replaces an own property of the value with the attributes.
+func Define(val, key, prop Value, attrs Attribute) {
+	obj := properties(val)
+	if obj == nil {
+		return
+	}
+	if key, ok := key.(*Private); ok {
+		obj.SetPrivate(key, prop)
+		return
+	}
+	k := ToPropertyKey(key)
+	obj.Set(k, prop)
+	obj.SetAttributes(k, attrs)
+}
+
+// DefineAccessor defines the getter or the setter of an accessor property, keeping the other one.
+func DefineAccessor(val, key, getter, setter Value, attrs Attribute) {
+	obj := properties(val)
+	if obj == nil {
+		return
+	}
+	var prop Value
+	if private, ok := key.(*Private); ok {
+		prop, _ = obj.Private(private)
+	} else {
+		prop, _ = obj.Get(ToPropertyKey(key))
+	}
+	if acc, ok := prop.(*Accessor); ok {
+		if getter == nil {
+			getter = acc.getter
+		}
+		if setter == nil {
+			setter = acc.setter
+		}
+	}
+	Define(val, key, NewAccessor(getter, setter), attrs)
+}
+
+// Descriptor describes a property, leaving out what is missing from its mask.
+type Descriptor struct {
+	Value      Value
+	Getter     Value
+	Setter     Value
+	Attributes Attribute
+	Mask       Attribute
+}
+
+func (d Descriptor) Accessor() bool {
+	return d.Getter != nil || d.Setter != nil
+}
+
+func (d Descriptor) Data() bool {
+	return d.Value != nil || d.Mask&WRITABLE != 0
+}
+
+// GetOwnProperty describes the own property of the value under the key.
+func GetOwnProperty(val, key Value) (Descriptor, bool) {
+	prop, ok := own(val, key)
+	if !ok {
+		return Descriptor{}, false
+	}
+	attrs := attributes(val, key)
+	if acc, ok := prop.(*Accessor); ok {
+		return Descriptor{
+			Getter:     defined(acc.getter),
+			Setter:     defined(acc.setter),
+			Attributes: attrs &^ WRITABLE,
+			Mask:       ENUMERABLE | CONFIGURABLE,
+		}, true
+	}
+	return Descriptor{Value: prop, Attributes: attrs, Mask: WRITABLE | ENUMERABLE | CONFIGURABLE}, true
+}
+
+// DefineProperty creates or changes an own property of the value as the descriptor describes.
+func DefineProperty(val, key Value, desc Descriptor) error {
+	obj := properties(val)
+	if obj == nil {
+		return fmt.Errorf("cannot define property on %s", string(ToString(val)))
+	}
+	name := ToPropertyKey(key)
+
+	current, ok := GetOwnProperty(val, key)
+	if !ok {
+		if !writable(val, key) {
+			return fmt.Errorf("cannot define property %s, object is not extensible", string(name))
+		}
+		current = Descriptor{Value: Undefined{}}
+		if desc.Accessor() {
+			current = Descriptor{Getter: Undefined{}, Setter: Undefined{}}
+		}
+	} else if current.Attributes&CONFIGURABLE == 0 && !compatible(current, desc) {
+		return fmt.Errorf("cannot redefine property: %s", string(name))
+	}
+
+	attrs := current.Attributes&^desc.Mask | desc.Attributes&desc.Mask
+	var prop Value
+	switch {
+	case desc.Accessor():
+		getter, setter := desc.Getter, desc.Setter
+		if getter == nil {
+			getter = current.Getter
+		}
+		if setter == nil {
+			setter = current.Setter
+		}
+		prop = NewAccessor(callable(getter), callable(setter))
+		attrs &^= WRITABLE
+	case desc.Data() || !current.Accessor():
+		prop = desc.Value
+		if prop == nil {
+			prop = current.Value
+		}
+		if prop == nil {
+			prop = Undefined{}
+		}
+	default:
+		prop = NewAccessor(callable(current.Getter), callable(current.Setter))
+	}
+
+	if arr, ok := val.(*Array); ok && name == "length" {
+		n, ok := arrayLength(prop)
+		if !ok {
+			return rangeError("invalid array length")
+		}
+		if !truncate(arr, n) {
+			return fmt.Errorf("cannot redefine property: %s", string(name))
+		}
+	} else {
+		write(val, key, prop)
+	}
+	obj.SetAttributes(name, attrs)
+	return nil
+}
+
+// Delete removes a property of the value, reporting whether it no longer exists.
+func Delete(val, key Value) (Bool, error) {
+	if IsNullish(val) {
+		return 0, fmt.Errorf("cannot convert %s to object", string(ToString(val)))
+	}
+
+	if _, ok := own(val, key); ok && attributes(val, key)&CONFIGURABLE == 0 {
+		return Bool(0), nil
+	}
+
+	switch val := val.(type) {
+	case *Object:
+		return NewBool(val.Delete(ToPropertyKey(key))), nil
+	case *Array:
+		if idx, ok := arrayIndex(key); ok {
+			val.DeleteIndex(idx)
+		}
+		return NewBool(val.Object().Delete(ToPropertyKey(key))), nil
+	case *Arguments:
+		return NewBool(val.Object().Delete(ToPropertyKey(key))), nil
+	case *Function:
+		return NewBool(val.Object().Delete(ToPropertyKey(key))), nil
+	default:
+		return Bool(1), nil
+	}
+}
+
+// OwnKeys returns the keys of every own property of the value.
+func OwnKeys(val Value) []String {
+	var keys []String
+	switch val := val.(type) {
+	case String:
+		for i := range utf16.Encode([]rune(string(val))) {
+			keys = append(keys, String(strconv.Itoa(i)))
+		}
+		return append(keys, "length")
+	case *Array:
+		for _, idx := range val.Indices() {
+			keys = append(keys, String(strconv.FormatUint(uint64(idx), 10)))
+		}
+		keys = append(keys, "length")
+	case *Arguments:
+		for i := 0; i < val.Len(); i++ {
+			keys = append(keys, String(strconv.Itoa(i)))
+		}
+		keys = append(keys, "length")
+	case *Function:
+		keys = append(keys, "length", "name")
+	default:
+	}
+	if obj := properties(val); obj != nil {
+		keys = append(keys, obj.OwnKeys()...)
+	}
+	return keys
+}
+
+func PreventExtensions(val Value) {
+	if obj := properties(val); obj != nil {
+		obj.PreventExtensions()
+	}
+}
+
+func Freeze(val Value) {
+	restrict(val, WRITABLE|CONFIGURABLE)
+}
+
+func Seal(val Value) {
+	restrict(val, CONFIGURABLE)
+}
+
+func IsFrozen(val Value) bool {
+	return restricted(val, WRITABLE|CONFIGURABLE)
+}
+
+func IsSealed(val Value) bool {
+	return restricted(val, CONFIGURABLE)
+}
+
+func IsExtensible(val Value) bool {
+	obj := properties(val)
+	return obj != nil && obj.Extensible()
+}
+
+// Prototype returns the object the value inherits properties from, which is null for primitives.
+func Prototype(val Value) Value {
+	if obj := properties(val); obj != nil {
+		return obj.Prototype()
+	}
+	return Null{}
+}
+
+// SetPrototype replaces the prototype of the value unless it makes the chain cyclic.
+func SetPrototype(val, proto Value) error {
+	obj := properties(val)
+	if obj == nil {
+		return nil
+	}
+	if object(proto) && (proto == val || inherits(proto, val)) {
+		return fmt.Errorf("cyclic __proto__ value")
+	}
+	if !obj.Extensible() && proto != obj.Prototype() {
+		return fmt.Errorf("%s is not extensible", string(ToString(val)))
+	}
+	obj.SetPrototype(proto)
+	return nil
+}
+
+// Extend makes the constructor and its instances inherit from the parent, which may be null.
+func Extend(fn *Function, parent Value) error {
+	proto := Value(Null{})
+	switch parent := parent.(type) {
+	case Null:
+	case *Function:
+		if !parent.Constructor() {
+			return fmt.Errorf("class extends value %s is not a constructor or null", string(ToString(parent)))
+		}
+		val, err := Get(parent, String("prototype"))
+		if err != nil {
+			return err
+		}
+		if _, ok := val.(Null); !ok && !object(val) {
+			return fmt.Errorf("class extends value does not have valid prototype property %s", string(ToString(val)))
+		}
+		proto = val
+		fn.Object().SetPrototype(parent)
+	default:
+		return fmt.Errorf("class extends value %s is not a constructor or null", string(ToString(parent)))
+	}
+	prototype, err := Get(fn, String("prototype"))
+	if err != nil {
+		return err
+	}
+	return SetPrototype(prototype, proto)
+}
+
+// InstanceOf applies the instanceof operator of ECMAScript.
+func InstanceOf(val, constructor Value) (Bool, error) {
+	fn, ok := constructor.(*Function)
+	if !ok {
+		return 0, fmt.Errorf("right-hand side of 'instanceof' is not callable")
+	}
+	proto, err := Get(fn, String("prototype"))
+	if err != nil {
+		return 0, err
+	}
+	if !object(proto) {
+		return 0, fmt.Errorf("function has non-object prototype '%s' in instanceof check", string(ToString(proto)))
+	}
+	return NewBool(object(val) && inherits(val, proto)), nil
+}
+
+// own reads an own property of the value, including the ones built into its kind.
+func own(val, key Value) (Value, bool) {
+	switch val := val.(type) {
+	case String:
+		units := utf16.Encode([]rune(string(val)))
+		prop := string(ToString(key))
+		if prop == "length" {
+			return Int32(len(units)), true
+		}
+		if idx, err := strconv.Atoi(prop); err == nil && idx >= 0 && idx < len(units) && strconv.Itoa(idx) == prop {
+			return String(utf16.Decode(units[idx : idx+1])), true
+		}
+	case *Array:
+		if idx, ok := arrayIndex(key); ok {
+			return val.Index(idx)
+		}
+		prop := ToPropertyKey(key)
+		if prop == "length" {
+			if n := val.Len(); n > math.MaxInt32 {
+				return Float64(n), true
+			}
+			return Int32(val.Len()), true
+		}
+		return val.Object().Get(prop)
+	case *Arguments:
+		if idx, ok := arrayIndex(key); ok && int64(idx) < int64(val.Len()) {
+			return val.At(int(idx)), true
+		}
+		prop := ToPropertyKey(key)
+		if prop == "length" {
+			return Int32(val.Len()), true
+		}
+		return val.Object().Get(prop)
+	case *Object:
+		return val.Get(ToPropertyKey(key))
+	case *Function:
+		switch prop := ToPropertyKey(key); prop {
+		case "name":
+			return val.Name(), true
+		case "length":
+			return val.Length(), true
+		default:
+			return val.Object().Get(prop)
+		}
+	default:
+	}
+	return nil, false
+}
+
+func accessor(val, key Value) (*Accessor, bool) {
+	if key, ok := key.(*Private); ok {
+		prop, _ := private(val, key)
+		acc, ok := prop.(*Accessor)
+		return acc, ok
+	}
+	for obj := val; !IsNullish(obj); obj = Prototype(obj) {
+		if prop, ok := own(obj, key); ok {
+			acc, ok := prop.(*Accessor)
+			return acc, ok
+		}
+	}
+	return nil, false
+}
+
+// private looks up the private member along the chain of the value.
+func private(val Value, key *Private) (Value, bool) {
+	for obj := val; !IsNullish(obj); obj = Prototype(obj) {
+		if o := properties(obj); o != nil {
+			if prop, ok := o.Private(key); ok {
+				return prop, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// attributes returns the attributes of the own property of the value under the key.
+func attributes(val, key Value) Attribute {
+	k := ToPropertyKey(key)
+	switch val.(type) {
+	case String:
+		if k == "length" {
+			return 0
+		}
+		return ENUMERABLE
+	case *Function:
+		if k == "name" || k == "length" {
+			return 0
+		}
+	default:
+	}
+	obj := properties(val)
+	if obj == nil {
+		return 0
+	}
+	if attrs, ok := obj.Attributes(k); ok {
+		return attrs
+	}
+	if k == "length" {
+		switch val.(type) {
+		case *Array:
+			return WRITABLE
+		case *Arguments:
+			return WRITABLE | CONFIGURABLE
+		default:
+		}
+	}
+	return WRITABLE | ENUMERABLE | CONFIGURABLE
+}
+
+// writable reports whether assigning the property of the value may create or change an own property.
+func writable(val, key Value) bool {
+	for obj := val; !IsNullish(obj); obj = Prototype(obj) {
+		prop, ok := own(obj, key)
+		if !ok {
+			continue
+		}
+		if _, ok := prop.(*Accessor); ok || attributes(obj, key)&WRITABLE == 0 {
+			return false
+		}
+		if obj == val {
+			return true
+		}
+		break
+	}
+	return extensible(val, key)
+}
+
+// extensible reports whether the property may be added to the value.
+func extensible(val, key Value) bool {
+	if arr, ok := val.(*Array); ok {
+		if idx, ok := arrayIndex(key); ok && int64(idx) >= int64(arr.Len()) && attributes(arr, String("length"))&WRITABLE == 0 {
+			return false
+		}
+	}
+	obj := properties(val)
+	return obj != nil && obj.Extensible()
+}
+
+// truncate sets the length of the array unless it would delete elements that are not configurable.
+func truncate(arr *Array, n uint32) bool {
+	obj := arr.Object()
+	limit := n
+	for key, attrs := range obj.attributes {
+		if idx, ok := index(key); ok && idx >= limit && attrs&CONFIGURABLE == 0 {
+			limit = idx + 1
+		}
+	}
+	for key := range obj.attributes {
+		if idx, ok := index(key); ok && idx >= limit {
+			delete(obj.attributes, key)
+		}
+	}
+	arr.SetLen(limit)
+	return limit == n
+}
+
+// write stores an own property of the value without regard to its attributes.
+func write(val, key, prop Value) {
+	switch val := val.(type) {
+	case *Array:
+		if idx, ok := arrayIndex(key); ok {
+			val.SetIndex(idx, prop)
+			return
+		}
+	case *Arguments:
+		if idx, ok := arrayIndex(key); ok && int64(idx) < int64(val.Len()) {
+			val.SetAt(int(idx), prop)
+			return
+		}
+	case *Function:
+		if k := ToPropertyKey(key); k == "name" || k == "length" {
+			return
+		}
+	default:
+	}
+	if obj := properties(val); obj != nil {
+		obj.Set(ToPropertyKey(key), prop)
+	}
+}
+
+// compatible reports whether the descriptor only makes the non-configurable property read-only, if anything.
+func compatible(current, desc Descriptor) bool {
+	if desc.Mask&CONFIGURABLE != 0 && desc.Attributes&CONFIGURABLE != 0 {
+		return false
+	}
+	if desc.Mask&ENUMERABLE != 0 && (desc.Attributes^current.Attributes)&ENUMERABLE != 0 {
+		return false
+	}
+	if current.Accessor() {
+		if desc.Data() {
+			return false
+		}
+		return (desc.Getter == nil || same(desc.Getter, current.Getter)) && (desc.Setter == nil || same(desc.Setter, current.Setter))
+	}
+	if desc.Accessor() {
+		return false
+	}
+	if current.Attributes&WRITABLE != 0 {
+		return true
+	}
+	if desc.Mask&WRITABLE != 0 && desc.Attributes&WRITABLE != 0 {
+		return false
+	}
+	return desc.Value == nil || same(desc.Value, current.Value)
+}
+
+func restrict(val Value, attrs Attribute) {
+	obj := properties(val)
+	if obj == nil {
+		return
+	}
+	obj.PreventExtensions()
+	for _, key := range OwnKeys(val) {
+		desc, _ := GetOwnProperty(val, key)
+		mask := attrs
+		if desc.Accessor() {
+			mask &^= WRITABLE
+		}
+		obj.SetAttributes(key, desc.Attributes&^mask)
+	}
+}
+
+func restricted(val Value, attrs Attribute) bool {
+	obj := properties(val)
+	if obj == nil {
+		return true
+	}
+	if obj.Extensible() {
+		return false
+	}
+	for _, key := range OwnKeys(val) {
+		desc, _ := GetOwnProperty(val, key)
+		mask := attrs
+		if desc.Accessor() {
+			mask &^= WRITABLE
+		}
+		if desc.Attributes&mask != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func defined(fn Value) Value {
+	if fn == nil {
+		return Undefined{}
+	}
+	return fn
+}
+
+func callable(fn Value) Value {
+	if _, ok := fn.(Undefined); ok {
+		return nil
+	}
+	return fn
+}
+
+// same applies the SameValue comparison of ECMAScript.
+func same(left, right Value) bool {
+	if left.Type() == INT32 || right.Type() == INT32 {
+		if left.Type() == FLOAT64 || right.Type() == FLOAT64 {
+			left, right = ToNumber(left), ToNumber(right)
+		}
+	}
+	if l, ok := left.(Float64); ok {
+		if r, ok := right.(Float64); ok {
+			if math.IsNaN(float64(l)) && math.IsNaN(float64(r)) {
+				return true
+			}
+			return l == r && math.Signbit(float64(l)) == math.Signbit(float64(r))
+		}
+	}
+	return StrictEqual(left, right)
+}
+
+// properties returns the object holding the properties of the value, which is nil for primitives.
+func properties(val Value) *Object {
+	switch val := val.(type) {
+	case *Object:
+		return val
+	case *Array:
+		return val.Object()
+	case *Arguments:
+		return val.Object()
+	case *Function:
+		return val.Object()
+	default:
+		return nil
+	}
+}
+
+func inherits(val, proto Value) bool {
+	for p := Prototype(val); !IsNullish(p); p = Prototype(p) {
+		if p == proto {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal applies the abstract equality comparison of ECMAScript.
+func Equal(left, right Value) bool {
+	if left == nil {
+		left = Undefined{}
+	}
+	if right == nil {
+		right = Undefined{}
+	}
+
+	if left.Type() == right.Type() {
+		return StrictEqual(left, right)
+	}
+
+	nullish := func(val Value) bool {
+		return val.Type() == UNDEFINED || val.Type() == NULL
+	}
+	if nullish(left) || nullish(right) {
+		return nullish(left) && nullish(right)
+	}
+	if object(left) || object(right) {
+		if object(left) && object(right) {
+			return false
+		}
+		return Equal(ToPrimitive(left), ToPrimitive(right))
+	}
+	return ToNumber(left) == ToNumber(right)
+}
+
+// StrictEqual applies the strict equality comparison of ECMAScript.
+func StrictEqual(left, right Value) bool {
+	if left == nil {
+		left = Undefined{}
+	}
+	if right == nil {
+		right = Undefined{}
+	}
+
+	switch left.(type) {
+	case Int32, Float64:
+		switch right.(type) {
+		case Int32, Float64:
+			return ToNumber(left) == ToNumber(right)
+		default:
+			return false
+		}
+	default:
+		return left == right
+	}
+}
+
+// LessThan applies the abstract relational comparison of ECMAScript, reporting false when either operand is NaN.
+func LessThan(left, right Value) (bool, bool) {
+	left, right = ToPrimitive(left), ToPrimitive(right)
+
+	val1, ok1 := left.(String)
+	val2, ok2 := right.(String)
+	if ok1 && ok2 {
+		return compare(val1, val2) < 0, true
+	}
+
+	num1 := ToNumber(left)
+	num2 := ToNumber(right)
+	if math.IsNaN(float64(num1)) || math.IsNaN(float64(num2)) {
+		return false, false
+	}
+	return num1 < num2, true
+}
+
+// compare orders the strings by their UTF-16 code units.
+func compare(left, right String) int {
+	return slices.Compare(utf16.Encode([]rune(string(left))), utf16.Encode([]rune(string(right))))
+}
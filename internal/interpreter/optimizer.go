@@ -21,26 +21,66 @@ func (o *Optimizer) Optimize(code bytecode.Bytecode) (bytecode.Bytecode, error)
 	constants := code.Constants
 
 	var instructions []bytecode.Instruction
+	indexes := map[int]int{}
 	for offset := 0; offset < len(code.Instructions); {
 		inst, size := code.Fetch(offset)
+		indexes[offset] = len(instructions)
 		instructions = append(instructions, inst)
 		offset += size
 	}
+	indexes[len(code.Instructions)] = len(instructions)
 
-	instructions, constants, err := o.fusion(instructions, constants)
+	jumps := map[int]int{}
+	for i, inst := range instructions {
+		if o.jump(inst) {
+			jumps[i] = indexes[int(inst.Operands()[0])]
+		}
+	}
+
+	handlers := make([]bytecode.Handler, len(code.Handlers))
+	for i, h := range code.Handlers {
+		handlers[i] = bytecode.Handler{Start: indexes[h.Start], End: indexes[h.End], Target: indexes[h.Target]}
+	}
+
+	instructions, constants, err := o.fusion(instructions, constants, jumps, handlers)
 	if err != nil {
 		return bytecode.Bytecode{}, err
 	}
 
-	instructions, constants = o.compress(instructions, constants)
+	positions := make([]bytecode.Position, len(code.Positions))
+	for i, p := range code.Positions {
+		positions[i] = bytecode.Position{Offset: indexes[p.Offset], Line: p.Line, Column: p.Column}
+	}
+
+	instructions, constants = o.compress(instructions, constants, jumps, handlers, positions)
 
 	code.Instructions = nil
 	code.Constants = constants
+	code.Handlers = handlers
+	code.Positions = positions
 	code.Emit(instructions...)
 	return code, nil
 }
 
-func (o *Optimizer) fusion(instructions []bytecode.Instruction, constants []byte) ([]bytecode.Instruction, []byte, error) {
+func (o *Optimizer) fusion(instructions []bytecode.Instruction, constants []byte, jumps map[int]int, handlers []bytecode.Handler) ([]bytecode.Instruction, []byte, error) {
+	targets := map[int]bool{}
+	for _, target := range jumps {
+		targets[target] = true
+	}
+	for _, h := range handlers {
+		targets[h.Start] = true
+		targets[h.End] = true
+		targets[h.Target] = true
+	}
+	reachable := func(from, to int) bool {
+		for i := from + 1; i <= to; i++ {
+			if targets[i] {
+				return true
+			}
+		}
+		return false
+	}
+
 	literals := map[string]int{}
 	for i := 0; i < len(instructions); i++ {
 		inst := instructions[i]
@@ -63,11 +103,15 @@ func (o *Optimizer) fusion(instructions []bytecode.Instruction, constants []byte
 				}
 			}
 
+			if reachable(j, i) {
+				continue
+			}
+
 			operand := instructions[j]
 			switch operand.Opcode() {
 			case bytecode.UNDEFLOAD, bytecode.NULLLOAD, bytecode.BOOLLOAD, bytecode.I32LOAD, bytecode.F64LOAD, bytecode.STRLOAD:
 				switch inst.Opcode() {
-				case bytecode.I32TOBOOL:
+				case bytecode.UNDEFTOBOOL, bytecode.NULLTOBOOL, bytecode.I32TOBOOL, bytecode.F64TOBOOL, bytecode.STRTOBOOL, bytecode.ANYTOBOOL, bytecode.ANYNULLISH, bytecode.BOOLNOT:
 					code := bytecode.Bytecode{Constants: constants}
 					code.Emit(operand, inst)
 					if err := o.interpreter.Execute(code); err != nil {
@@ -78,7 +122,7 @@ func (o *Optimizer) fusion(instructions []bytecode.Instruction, constants []byte
 
 					instructions[j] = bytecode.New(bytecode.NOP)
 					instructions[i] = bytecode.New(bytecode.BOOLLOAD, uint64(val))
-				case bytecode.NULLTOI32, bytecode.BOOLTOI32, bytecode.F64TOI32, bytecode.STRTOI32:
+				case bytecode.NULLTOI32, bytecode.BOOLTOI32, bytecode.F64TOI32, bytecode.STRTOI32, bytecode.ANYTOI32, bytecode.I32NOT:
 					code := bytecode.Bytecode{Constants: constants}
 					code.Emit(operand, inst)
 					if err := o.interpreter.Execute(code); err != nil {
@@ -89,7 +133,7 @@ func (o *Optimizer) fusion(instructions []bytecode.Instruction, constants []byte
 
 					instructions[j] = bytecode.New(bytecode.NOP)
 					instructions[i] = bytecode.New(bytecode.I32LOAD, uint64(val))
-				case bytecode.UNDEFTOF64, bytecode.I32TOF64, bytecode.STRTOF64:
+				case bytecode.UNDEFTOF64, bytecode.I32TOF64, bytecode.STRTOF64, bytecode.ANYTOF64:
 					code := bytecode.Bytecode{Constants: constants}
 					code.Emit(operand, inst)
 					if err := o.interpreter.Execute(code); err != nil {
@@ -100,7 +144,7 @@ func (o *Optimizer) fusion(instructions []bytecode.Instruction, constants []byte
 
 					instructions[j] = bytecode.New(bytecode.NOP)
 					instructions[i] = bytecode.New(bytecode.F64LOAD, math.Float64bits(float64(val)))
-				case bytecode.UNDEFTOSTR, bytecode.NULLTOSTR, bytecode.BOOLTOSTR, bytecode.I32TOSTR, bytecode.F64TOSTR:
+				case bytecode.UNDEFTOSTR, bytecode.NULLTOSTR, bytecode.BOOLTOSTR, bytecode.I32TOSTR, bytecode.F64TOSTR, bytecode.ANYTOSTR, bytecode.ANYTYPEOF:
 					code := bytecode.Bytecode{Constants: constants}
 					code.Emit(operand, inst)
 					if err := o.interpreter.Execute(code); err != nil {
@@ -138,26 +182,35 @@ func (o *Optimizer) fusion(instructions []bytecode.Instruction, constants []byte
 					break
 				}
 			}
+			if k < 0 {
+				continue
+			}
 
 			operand1 := instructions[j]
 			operand2 := instructions[k]
-			if operand1.Opcode() == operand2.Opcode() {
+			if operand1.Opcode() == operand2.Opcode() && !reachable(k, i) {
 				switch operand1.Opcode() {
 				case bytecode.BOOLLOAD, bytecode.I32LOAD, bytecode.F64LOAD, bytecode.STRLOAD:
 					switch inst.Opcode() {
-					case bytecode.I32ADD, bytecode.I32SUB, bytecode.I32MUL, bytecode.I32DIV, bytecode.I32MOD:
+					case bytecode.I32ADD, bytecode.I32SUB, bytecode.I32MUL, bytecode.I32DIV, bytecode.I32MOD,
+						bytecode.I32AND, bytecode.I32OR, bytecode.I32XOR, bytecode.I32SHL, bytecode.I32SHR:
 						code := bytecode.Bytecode{Constants: constants}
 						code.Emit(operand2, operand1, inst)
 						if err := o.interpreter.Execute(code); err != nil {
 							return nil, nil, err
 						}
 
-						val, _ := o.interpreter.Pop().(Int32)
+						val := o.interpreter.Pop()
 
 						instructions[k] = bytecode.New(bytecode.NOP)
 						instructions[j] = bytecode.New(bytecode.NOP)
-						instructions[i] = bytecode.New(bytecode.I32LOAD, uint64(val))
-					case bytecode.F64ADD, bytecode.F64SUB, bytecode.F64MUL, bytecode.F64DIV, bytecode.F64MOD:
+						if val, ok := val.(Float64); ok {
+							instructions[i] = bytecode.New(bytecode.F64LOAD, math.Float64bits(float64(val)))
+							break
+						}
+						v, _ := val.(Int32)
+						instructions[i] = bytecode.New(bytecode.I32LOAD, uint64(v))
+					case bytecode.F64ADD, bytecode.F64SUB, bytecode.F64MUL, bytecode.F64DIV, bytecode.F64MOD, bytecode.F64POW, bytecode.I32USHR:
 						code := bytecode.Bytecode{Constants: constants}
 						code.Emit(operand2, operand1, inst)
 						if err := o.interpreter.Execute(code); err != nil {
@@ -169,6 +222,20 @@ func (o *Optimizer) fusion(instructions []bytecode.Instruction, constants []byte
 						instructions[k] = bytecode.New(bytecode.NOP)
 						instructions[j] = bytecode.New(bytecode.NOP)
 						instructions[i] = bytecode.New(bytecode.F64LOAD, math.Float64bits(float64(val)))
+					case bytecode.I32EQ, bytecode.I32NE, bytecode.I32LT, bytecode.I32GT, bytecode.I32LE, bytecode.I32GE,
+						bytecode.F64EQ, bytecode.F64NE, bytecode.F64LT, bytecode.F64GT, bytecode.F64LE, bytecode.F64GE,
+						bytecode.STREQ, bytecode.STRNE, bytecode.STRLT, bytecode.STRGT, bytecode.STRLE, bytecode.STRGE:
+						code := bytecode.Bytecode{Constants: constants}
+						code.Emit(operand2, operand1, inst)
+						if err := o.interpreter.Execute(code); err != nil {
+							return nil, nil, err
+						}
+
+						val, _ := o.interpreter.Pop().(Bool)
+
+						instructions[k] = bytecode.New(bytecode.NOP)
+						instructions[j] = bytecode.New(bytecode.NOP)
+						instructions[i] = bytecode.New(bytecode.BOOLLOAD, uint64(val))
 					case bytecode.STRADD:
 						code := bytecode.Bytecode{Constants: constants}
 						code.Emit(operand2, operand1, inst)
@@ -198,16 +265,30 @@ func (o *Optimizer) fusion(instructions []bytecode.Instruction, constants []byte
 	return instructions, constants, nil
 }
 
-func (o *Optimizer) compress(instructions []bytecode.Instruction, constants []byte) ([]bytecode.Instruction, []byte) {
+func (o *Optimizer) compress(instructions []bytecode.Instruction, constants []byte, jumps map[int]int, handlers []bytecode.Handler, positions []bytecode.Position) ([]bytecode.Instruction, []byte) {
 	literals := map[string]int{}
 	for i := 0; i < len(instructions); i++ {
 		inst := instructions[i]
-		if inst.Opcode() == bytecode.STRLOAD {
+		switch inst.Opcode() {
+		case bytecode.STRLOAD, bytecode.BUILTINLOAD:
 			offset := int(binary.BigEndian.Uint32(inst[1:]))
 			size := int(binary.BigEndian.Uint32(inst[5:]))
 
 			literal := string(constants[offset : offset+size])
 			literals[literal] = offset
+		case bytecode.SLTCHECK, bytecode.CELLCHECK, bytecode.UPVCHECK, bytecode.GLBCHECK, bytecode.GLBHOLE:
+			offset := int(binary.BigEndian.Uint32(inst[3:]))
+			size := int(binary.BigEndian.Uint32(inst[7:]))
+
+			literal := string(constants[offset : offset+size])
+			literals[literal] = offset
+		case bytecode.FNLOAD:
+			offset := int(binary.BigEndian.Uint32(inst[7:]))
+			size := int(binary.BigEndian.Uint32(inst[11:]))
+
+			literal := string(constants[offset : offset+size])
+			literals[literal] = offset
+		default:
 		}
 	}
 
@@ -219,18 +300,64 @@ func (o *Optimizer) compress(instructions []bytecode.Instruction, constants []by
 
 	for i := 0; i < len(instructions); i++ {
 		inst := instructions[i]
-		if inst.Opcode() == bytecode.STRLOAD {
+		switch inst.Opcode() {
+		case bytecode.STRLOAD, bytecode.BUILTINLOAD:
 			offset := int(binary.BigEndian.Uint32(inst[1:]))
 			size := int(binary.BigEndian.Uint32(inst[5:]))
-			instructions[i] = bytecode.New(bytecode.STRLOAD, uint64(literals[string(constants[offset:offset+size])]), uint64(size))
+			instructions[i] = bytecode.New(inst.Opcode(), uint64(literals[string(constants[offset:offset+size])]), uint64(size))
+		case bytecode.SLTCHECK, bytecode.CELLCHECK, bytecode.UPVCHECK, bytecode.GLBCHECK, bytecode.GLBHOLE:
+			idx := int(binary.BigEndian.Uint16(inst[1:]))
+			offset := int(binary.BigEndian.Uint32(inst[3:]))
+			size := int(binary.BigEndian.Uint32(inst[7:]))
+			instructions[i] = bytecode.New(inst.Opcode(), uint64(idx), uint64(literals[string(constants[offset:offset+size])]), uint64(size))
+		case bytecode.FNLOAD:
+			entry := int(binary.BigEndian.Uint32(inst[1:]))
+			arity := int(inst[5])
+			length := int(inst[6])
+			offset := int(binary.BigEndian.Uint32(inst[7:]))
+			size := int(binary.BigEndian.Uint32(inst[11:]))
+			flags := int(inst[15])
+			instructions[i] = bytecode.New(bytecode.FNLOAD, uint64(entry), uint64(arity), uint64(length), uint64(literals[string(constants[offset:offset+size])]), uint64(size), uint64(flags))
+		default:
 		}
 	}
 
-	for i := len(instructions) - 1; i >= 0; i-- {
-		if instructions[i].Opcode() == bytecode.NOP {
-			instructions = append(instructions[:i], instructions[i+1:]...)
+	indexes := make([]int, len(instructions)+1)
+	var compacted []bytecode.Instruction
+	for i, inst := range instructions {
+		indexes[i] = len(compacted)
+		if inst.Opcode() != bytecode.NOP {
+			compacted = append(compacted, inst)
 		}
 	}
+	indexes[len(instructions)] = len(compacted)
+
+	offsets := make([]int, len(compacted)+1)
+	for i, inst := range compacted {
+		offsets[i+1] = offsets[i] + len(inst)
+	}
+
+	for from, to := range jumps {
+		idx := indexes[from]
+		operands := compacted[idx].Operands()
+		operands[0] = uint64(offsets[indexes[to]])
+		compacted[idx] = bytecode.New(compacted[idx].Opcode(), operands...)
+	}
+	for i, h := range handlers {
+		handlers[i] = bytecode.Handler{Start: offsets[indexes[h.Start]], End: offsets[indexes[h.End]], Target: offsets[indexes[h.Target]]}
+	}
+	for i, p := range positions {
+		positions[i].Offset = offsets[indexes[p.Offset]]
+	}
 
-	return instructions, compressed
+	return compacted, compressed
+}
+
+func (o *Optimizer) jump(inst bytecode.Instruction) bool {
+	switch inst.Opcode() {
+	case bytecode.JMP, bytecode.JMPT, bytecode.JMPF, bytecode.FNLOAD:
+		return true
+	default:
+		return false
+	}
 }
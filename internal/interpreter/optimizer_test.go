@@ -14,6 +14,8 @@ func TestOptimizer_Optimize(t *testing.T) {
 		commands []bytecode.Instruction
 		expected []bytecode.Instruction
 		literals []string
+		handlers []bytecode.Handler
+		remapped []bytecode.Handler
 	}{
 		{
 			commands: []bytecode.Instruction{
@@ -116,6 +118,24 @@ func TestOptimizer_Optimize(t *testing.T) {
 				bytecode.New(bytecode.STRLOAD, 0, 1),
 			},
 		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.NULLLOAD),
+				bytecode.New(bytecode.ANYNULLISH),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+			},
+		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.ANYTYPEOF),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.STRLOAD, 0, 7),
+			},
+		},
 
 		{
 			commands: []bytecode.Instruction{
@@ -148,6 +168,16 @@ func TestOptimizer_Optimize(t *testing.T) {
 				bytecode.New(bytecode.I32LOAD, 2),
 			},
 		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, math.MaxInt32),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32ADD),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.MaxInt32+1)),
+			},
+		},
 		{
 			commands: []bytecode.Instruction{
 				bytecode.New(bytecode.I32LOAD, 1),
@@ -239,7 +269,69 @@ func TestOptimizer_Optimize(t *testing.T) {
 				bytecode.New(bytecode.F64LOAD, math.Float64bits(0)),
 			},
 		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(2)),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(10)),
+				bytecode.New(bytecode.F64POW),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1024)),
+			},
+		},
 
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32LT),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+			},
+		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.NaN())),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(math.NaN())),
+				bytecode.New(bytecode.F64EQ),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 0),
+			},
+		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.BOOLNOT),
+				bytecode.New(bytecode.JMPF, 14),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32TOF64),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 0),
+				bytecode.New(bytecode.JMPF, 16),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(1)),
+			},
+		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.JMPT, 14),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32TOBOOL),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.DUP),
+				bytecode.New(bytecode.JMPT, 14),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32TOBOOL),
+			},
+		},
 		{
 			commands: []bytecode.Instruction{
 				bytecode.New(bytecode.STRLOAD, 0, 3),
@@ -251,6 +343,83 @@ func TestOptimizer_Optimize(t *testing.T) {
 			},
 			literals: []string{"foo"},
 		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(2147483649)),
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(-1)),
+				bytecode.New(bytecode.F64MUL),
+				bytecode.New(bytecode.F64TOI32),
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.I32OR),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 2147483647),
+			},
+		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 0xFFFFFFFF),
+				bytecode.New(bytecode.I32LOAD, 0),
+				bytecode.New(bytecode.I32USHR),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.F64LOAD, math.Float64bits(4294967295)),
+			},
+		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.SLTCHECK, 0, 4, 1),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.SLTCHECK, 0, 0, 1),
+			},
+			literals: []string{"foo", "a"},
+		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.UPVCHECK, 0, 4, 1),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.UPVCHECK, 0, 0, 1),
+			},
+			literals: []string{"foo", "a"},
+		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.I32LOAD, 2),
+				bytecode.New(bytecode.I32ADD),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.JMP, 19),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 17, 0, 0, 0, 1, 1),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 3),
+				bytecode.New(bytecode.POP),
+				bytecode.New(bytecode.JMP, 13),
+				bytecode.New(bytecode.UNDEFLOAD),
+				bytecode.New(bytecode.RETURN),
+				bytecode.New(bytecode.FNLOAD, 11, 0, 0, 0, 1, 1),
+			},
+			literals: []string{"f"},
+		},
+		{
+			commands: []bytecode.Instruction{
+				bytecode.New(bytecode.BOOLLOAD, 1),
+				bytecode.New(bytecode.BOOLTOI32),
+				bytecode.New(bytecode.THROW),
+				bytecode.New(bytecode.POP),
+			},
+			expected: []bytecode.Instruction{
+				bytecode.New(bytecode.I32LOAD, 1),
+				bytecode.New(bytecode.THROW),
+				bytecode.New(bytecode.POP),
+			},
+			handlers: []bytecode.Handler{{Start: 0, End: 4, Target: 4}},
+			remapped: []bytecode.Handler{{Start: 0, End: 6, Target: 6}},
+		},
 	}
 
 	optimizer := NewOptimizer()
@@ -261,9 +430,11 @@ func TestOptimizer_Optimize(t *testing.T) {
 		for _, c := range tt.literals {
 			commands.Store([]byte(c + "\x00"))
 		}
+		commands.Handlers = tt.handlers
 
 		expected := bytecode.Bytecode{}
 		expected.Emit(tt.expected...)
+		expected.Handlers = tt.remapped
 
 		t.Run(commands.String(), func(t *testing.T) {
 			acturl, err := optimizer.Optimize(commands)
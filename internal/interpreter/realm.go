@@ -0,0 +1,113 @@
+package interpreter
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/siyul-park/minijs/internal/bytecode"
+)
+
+// Realm holds the built-in values of an interpreter, so that changes made to them stay in it.
+type Realm struct {
+	ObjectPrototype   *Object
+	FunctionPrototype *Object
+	ArrayPrototype    *Object
+	StringPrototype   *Object
+	NumberPrototype   *Object
+	BooleanPrototype  *Object
+	ErrorPrototype    *Object
+	global            *Object
+	errors            map[String]*Object
+	globals           map[String]Value
+}
+
+// builtins holds the types of the globals every realm is built with.
+var builtins = func() map[String]Type {
+	types := make(map[String]Type)
+	for name, val := range NewRealm().globals {
+		types[name] = val.Type()
+	}
+	return types
+}()
+
+func NewRealm() *Realm {
+	r := &Realm{
+		ObjectPrototype: NewObject(Null{}),
+		errors:          make(map[String]*Object),
+		globals:         make(map[String]Value),
+	}
+	r.FunctionPrototype = NewObject(r.ObjectPrototype)
+	r.ArrayPrototype = NewObject(r.ObjectPrototype)
+	r.StringPrototype = NewObject(r.ObjectPrototype)
+	r.NumberPrototype = NewObject(r.ObjectPrototype)
+	r.BooleanPrototype = NewObject(r.ObjectPrototype)
+	r.ErrorPrototype = NewObject(r.ObjectPrototype)
+
+	r.objects()
+	r.errorTypes()
+
+	r.global = NewObject(r.ObjectPrototype)
+	r.globals["globalThis"] = r.global
+	for _, name := range slices.Sorted(maps.Keys(r.globals)) {
+		r.global.Define(name, r.globals[name])
+	}
+	return r
+}
+
+// Builtin returns the type of the global built in under the name.
+func Builtin(name String) (Type, bool) {
+	typ, ok := builtins[name]
+	return typ, ok
+}
+
+// Global returns the global value built in under the name.
+func (r *Realm) Global(name String) (Value, bool) {
+	val, ok := r.globals[name]
+	return val, ok
+}
+
+// Prototype returns the object the value inherits properties from, which is the prototype of its kind for primitives.
+func (r *Realm) Prototype(val Value) Value {
+	switch val.(type) {
+	case String:
+		return r.StringPrototype
+	case Int32, Float64:
+		return r.NumberPrototype
+	case Bool:
+		return r.BooleanPrototype
+	default:
+		return Prototype(val)
+	}
+}
+
+func (r *Realm) NewArray(elements ...Value) *Array {
+	return &Array{elements: elements, object: NewObject(r.ArrayPrototype)}
+}
+
+func (r *Realm) NewArguments(values ...Value) *Arguments {
+	return &Arguments{values: values, object: NewObject(r.ObjectPrototype)}
+}
+
+func (r *Realm) NewFunction(name String, length Int32, arity int, flags byte, code bytecode.Bytecode, entry int) *Function {
+	return &Function{name: name, length: length, arity: arity, flags: flags, code: code, entry: entry, realm: r}
+}
+
+func (r *Realm) NewNativeFunction(name String, length Int32, flags byte, native Native) *Function {
+	return &Function{name: name, length: length, flags: flags, native: native, realm: r}
+}
+
+// NewError creates an error of the kind named after its constructor.
+func (r *Realm) NewError(name String, message String) *Object {
+	proto, ok := r.errors[name]
+	if !ok {
+		proto = r.ErrorPrototype
+	}
+	obj := NewObject(proto)
+	obj.errorData = true
+	obj.Define("message", message)
+	return obj
+}
+
+func (r *Realm) method(obj Value, name String, length Int32, native Native) {
+	properties(obj).Define(name, r.NewNativeFunction(name, length, 0, native))
+}
@@ -22,6 +22,7 @@ const (
 	FLOAT64
 	STRING
 	OBJECT
+	FUNCTION
 )
 
 func (t Type) String() string {
@@ -42,6 +43,8 @@ func (t Type) String() string {
 		return "string"
 	case OBJECT:
 		return "object"
+	case FUNCTION:
+		return "function"
 	default:
 		return "<invalid>"
 	}
@@ -77,6 +80,13 @@ func (Null) String() string {
 
 type Bool int32
 
+func NewBool(b bool) Bool {
+	if b {
+		return Bool(1)
+	}
+	return Bool(0)
+}
+
 func (b Bool) Type() Type {
 	return BOOL
 }
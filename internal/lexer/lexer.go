@@ -28,6 +28,7 @@ func New(source io.Reader) *Lexer {
 func (l *Lexer) Next() token.Token {
 	l.hidden()
 
+	pos := token.Position{Line: l.line, Column: l.column}
 	var tk token.Token
 	switch ch := l.peek(0); ch {
 	case rune(0):
@@ -57,15 +58,29 @@ func (l *Lexer) Next() token.Token {
 			} else {
 				tk = token.New(token.EQUAL, l.read(2))
 			}
+		} else if l.peek(1) == '>' {
+			tk = token.New(token.ARROW, l.read(2))
 		} else {
 			tk = token.New(token.ASSIGN, l.read(1))
 		}
 	case '?':
-		tk = token.New(token.QUESTION, l.read(1))
+		if l.peek(1) == '?' && l.peek(2) == '=' {
+			tk = token.New(token.NULLISH_ASSIGN, l.read(3))
+		} else if l.peek(1) == '?' {
+			tk = token.New(token.NULLISH, l.read(2))
+		} else if l.peek(1) == '.' && !unicode.IsDigit(l.peek(2)) {
+			tk = token.New(token.OPTIONAL_CHAIN, l.read(2))
+		} else {
+			tk = token.New(token.QUESTION, l.read(1))
+		}
 	case ':':
 		tk = token.New(token.COLON, l.read(1))
 	case '.':
-		tk = token.New(token.DOT, l.read(1))
+		if l.peek(1) == '.' && l.peek(2) == '.' {
+			tk = token.New(token.ELLIPSIS, l.read(3))
+		} else {
+			tk = token.New(token.DOT, l.read(1))
+		}
 	case '~':
 		tk = token.New(token.BIT_NOT, l.read(1))
 	case '!':
@@ -95,7 +110,11 @@ func (l *Lexer) Next() token.Token {
 			tk = token.New(token.MINUS, l.read(1))
 		}
 	case '*':
-		if l.peek(1) == '=' {
+		if l.peek(1) == '*' && l.peek(2) == '=' {
+			tk = token.New(token.EXPONENT_ASSIGN, l.read(3))
+		} else if l.peek(1) == '*' {
+			tk = token.New(token.EXPONENT, l.read(2))
+		} else if l.peek(1) == '=' {
 			tk = token.New(token.MULTIPLY_ASSIGN, l.read(2))
 		} else {
 			tk = token.New(token.MULTIPLY, l.read(1))
@@ -113,7 +132,9 @@ func (l *Lexer) Next() token.Token {
 			tk = token.New(token.MODULUS, l.read(1))
 		}
 	case '&':
-		if l.peek(1) == '&' {
+		if l.peek(1) == '&' && l.peek(2) == '=' {
+			tk = token.New(token.AND_ASSIGN, l.read(3))
+		} else if l.peek(1) == '&' {
 			tk = token.New(token.AND, l.read(2))
 		} else if l.peek(1) == '=' {
 			tk = token.New(token.BIT_AND_ASSIGN, l.read(2))
@@ -121,7 +142,9 @@ func (l *Lexer) Next() token.Token {
 			tk = token.New(token.BIT_AND, l.read(1))
 		}
 	case '|':
-		if l.peek(1) == '|' {
+		if l.peek(1) == '|' && l.peek(2) == '=' {
+			tk = token.New(token.OR_ASSIGN, l.read(3))
+		} else if l.peek(1) == '|' {
 			tk = token.New(token.OR, l.read(2))
 		} else if l.peek(1) == '=' {
 			tk = token.New(token.BIT_OR_ASSIGN, l.read(2))
@@ -131,6 +154,8 @@ func (l *Lexer) Next() token.Token {
 	case '^':
 		if l.peek(1) == '=' {
 			tk = token.New(token.BIT_XOR_ASSIGN, l.read(2))
+		} else {
+			tk = token.New(token.BIT_XOR, l.read(1))
 		}
 	case '<':
 		if l.peek(1) == '=' {
@@ -162,9 +187,16 @@ func (l *Lexer) Next() token.Token {
 		} else {
 			tk = token.New(token.GREATER_THAN, l.read(1))
 		}
+	case '#':
+		if ch := l.peek(1); unicode.IsLetter(ch) || ch == '_' || ch == '$' {
+			tk = l.identifier()
+			tk = token.New(token.PRIVATE_IDENTIFIER, tk.Literal)
+		} else {
+			tk = token.New(token.ILLEGAL, l.read(1))
+		}
 	default:
 		if unicode.IsLetter(ch) || ch == '_' || ch == '$' {
-			return l.identifier()
+			tk = l.identifier()
 		} else if unicode.IsDigit(ch) {
 			tk = l.number()
 		} else {
@@ -172,6 +204,7 @@ func (l *Lexer) Next() token.Token {
 		}
 	}
 
+	tk.Pos = pos
 	return tk
 }
 
@@ -183,7 +216,7 @@ func (l *Lexer) number() token.Token {
 	if ch == '0' && (l.peek(1) == 'b' || l.peek(1) == 'B') {
 		return l.binaryInteger()
 	}
-	if ch == '0' {
+	if ch == '0' && (l.peek(1) == 'o' || l.peek(1) == 'O' || unicode.IsDigit(l.peek(1))) {
 		return l.octalInteger()
 	}
 	if ch == '.' || unicode.IsDigit(ch) {
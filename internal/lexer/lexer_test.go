@@ -11,19 +11,23 @@ import (
 
 func TestLexer_Next(t *testing.T) {
 	tests := []struct {
-		source string
-		tokens []token.Token
+		source    string
+		tokens    []token.Token
+		positions []token.Position
 	}{
 		{source: `// comment`, tokens: []token.Token{token.New(token.EOF, "")}},
 		{source: `/* comment */`, tokens: []token.Token{token.New(token.EOF, "")}},
 
 		{source: `123`, tokens: []token.Token{token.New(token.NUMBER, "123")}},
 		{source: `12.3`, tokens: []token.Token{token.New(token.NUMBER, "12.3")}},
+		{source: `0.5`, tokens: []token.Token{token.New(token.NUMBER, "0.5")}},
 		{source: `0x01`, tokens: []token.Token{token.New(token.NUMBER, "0x01")}},
 		{source: `0o01`, tokens: []token.Token{token.New(token.NUMBER, "0o01")}},
 		{source: `01`, tokens: []token.Token{token.New(token.NUMBER, "01")}},
 		{source: `0b01`, tokens: []token.Token{token.New(token.NUMBER, "0b01")}},
 
+		{source: `#foo`, tokens: []token.Token{token.New(token.PRIVATE_IDENTIFIER, "#foo")}},
+
 		{source: `"foo"`, tokens: []token.Token{token.New(token.STRING, "foo")}},
 		{source: `'foo''`, tokens: []token.Token{token.New(token.STRING, "foo")}},
 
@@ -37,6 +41,9 @@ func TestLexer_Next(t *testing.T) {
 		{source: `instanceof`, tokens: []token.Token{token.New(token.INSTANCEOF, "instanceof")}},
 		{source: `typeof`, tokens: []token.Token{token.New(token.TYPEOF, "typeof")}},
 		{source: `case`, tokens: []token.Token{token.New(token.CASE, "case")}},
+		{source: `class`, tokens: []token.Token{token.New(token.CLASS, "class")}},
+		{source: `extends`, tokens: []token.Token{token.New(token.EXTENDS, "extends")}},
+		{source: `super`, tokens: []token.Token{token.New(token.SUPER, "super")}},
 		{source: `else`, tokens: []token.Token{token.New(token.ELSE, "else")}},
 		{source: `new`, tokens: []token.Token{token.New(token.NEW, "new")}},
 		{source: `var`, tokens: []token.Token{token.New(token.VAR, "var")}},
@@ -58,6 +65,8 @@ func TestLexer_Next(t *testing.T) {
 		{source: `delete`, tokens: []token.Token{token.New(token.DELETE, "delete")}},
 		{source: `in`, tokens: []token.Token{token.New(token.IN, "in")}},
 		{source: `try`, tokens: []token.Token{token.New(token.TRY, "try")}},
+		{source: `let`, tokens: []token.Token{token.New(token.LET, "let")}},
+		{source: `const`, tokens: []token.Token{token.New(token.CONST, "const")}},
 
 		{source: `[`, tokens: []token.Token{token.New(token.OPEN_BRACKET, "[")}},
 		{source: `]`, tokens: []token.Token{token.New(token.CLOSE_BRACKET, "]")}},
@@ -68,9 +77,13 @@ func TestLexer_Next(t *testing.T) {
 		{source: `;`, tokens: []token.Token{token.New(token.SEMICOLON, ";")}},
 		{source: `,`, tokens: []token.Token{token.New(token.COMMA, ",")}},
 		{source: `=`, tokens: []token.Token{token.New(token.ASSIGN, "=")}},
+		{source: `=>`, tokens: []token.Token{token.New(token.ARROW, "=>")}},
 		{source: `?`, tokens: []token.Token{token.New(token.QUESTION, "?")}},
+		{source: `?.`, tokens: []token.Token{token.New(token.OPTIONAL_CHAIN, "?.")}},
+		{source: `?.5`, tokens: []token.Token{token.New(token.QUESTION, "?"), token.New(token.DOT, "."), token.New(token.NUMBER, "5")}},
 		{source: `:`, tokens: []token.Token{token.New(token.COLON, ":")}},
 		{source: `.`, tokens: []token.Token{token.New(token.DOT, ".")}},
+		{source: `...`, tokens: []token.Token{token.New(token.ELLIPSIS, "...")}},
 		{source: `+`, tokens: []token.Token{token.New(token.PLUS, "+")}},
 		{source: `-`, tokens: []token.Token{token.New(token.MINUS, "-")}},
 		{source: `++`, tokens: []token.Token{token.New(token.PLUS_PLUS, "++")}},
@@ -80,6 +93,7 @@ func TestLexer_Next(t *testing.T) {
 		{source: `*`, tokens: []token.Token{token.New(token.MULTIPLY, "*")}},
 		{source: `/`, tokens: []token.Token{token.New(token.DIVIDE, "/")}},
 		{source: `%`, tokens: []token.Token{token.New(token.MODULUS, "%")}},
+		{source: `**`, tokens: []token.Token{token.New(token.EXPONENT, "**")}},
 		{source: `>>`, tokens: []token.Token{token.New(token.RIGHT_SHIFT_ARITHMETIC, ">>")}},
 		{source: `<<`, tokens: []token.Token{token.New(token.LEFT_SHIFT_ARITHMETIC, "<<")}},
 		{source: `>>>`, tokens: []token.Token{token.New(token.RIGHT_SHIFT_LOGICAL, ">>>")}},
@@ -93,11 +107,14 @@ func TestLexer_Next(t *testing.T) {
 		{source: `!==`, tokens: []token.Token{token.New(token.IDENTITY_NOT_EQUAL, "!==")}},
 		{source: `&`, tokens: []token.Token{token.New(token.BIT_AND, "&")}},
 		{source: `|`, tokens: []token.Token{token.New(token.BIT_OR, "|")}},
+		{source: `^`, tokens: []token.Token{token.New(token.BIT_XOR, "^")}},
 		{source: `&&`, tokens: []token.Token{token.New(token.AND, "&&")}},
 		{source: `||`, tokens: []token.Token{token.New(token.OR, "||")}},
+		{source: `??`, tokens: []token.Token{token.New(token.NULLISH, "??")}},
 		{source: `*=`, tokens: []token.Token{token.New(token.MULTIPLY_ASSIGN, "*=")}},
 		{source: `/=`, tokens: []token.Token{token.New(token.DIVIDE_ASSIGN, "/=")}},
 		{source: `%=`, tokens: []token.Token{token.New(token.MODULUS_ASSIGN, "%=")}},
+		{source: `**=`, tokens: []token.Token{token.New(token.EXPONENT_ASSIGN, "**=")}},
 		{source: `+=`, tokens: []token.Token{token.New(token.PLUS_ASSIGN, "+=")}},
 		{source: `-=`, tokens: []token.Token{token.New(token.MINUS_ASSIGN, "-=")}},
 		{source: `<<=`, tokens: []token.Token{token.New(token.LEFT_SHIFT_ARITHMETIC_ASSIGN, "<<=")}},
@@ -106,13 +123,46 @@ func TestLexer_Next(t *testing.T) {
 		{source: `&=`, tokens: []token.Token{token.New(token.BIT_AND_ASSIGN, "&=")}},
 		{source: `|=`, tokens: []token.Token{token.New(token.BIT_OR_ASSIGN, "|=")}},
 		{source: `^=`, tokens: []token.Token{token.New(token.BIT_XOR_ASSIGN, "^=")}},
+		{source: `&&=`, tokens: []token.Token{token.New(token.AND_ASSIGN, "&&=")}},
+		{source: `||=`, tokens: []token.Token{token.New(token.OR_ASSIGN, "||=")}},
+		{source: `??=`, tokens: []token.Token{token.New(token.NULLISH_ASSIGN, "??=")}},
+
+		{
+			source: "a = 1;\n  foo(\"x\")",
+			tokens: []token.Token{
+				token.New(token.IDENTIFIER, "a"),
+				token.New(token.ASSIGN, "="),
+				token.New(token.NUMBER, "1"),
+				token.New(token.SEMICOLON, ";"),
+				token.New(token.IDENTIFIER, "foo"),
+				token.New(token.OPEN_PAREN, "("),
+				token.New(token.STRING, "x"),
+				token.New(token.CLOSE_PAREN, ")"),
+				token.New(token.EOF, ""),
+			},
+			positions: []token.Position{
+				{Line: 1, Column: 1},
+				{Line: 1, Column: 3},
+				{Line: 1, Column: 5},
+				{Line: 1, Column: 6},
+				{Line: 2, Column: 3},
+				{Line: 2, Column: 6},
+				{Line: 2, Column: 7},
+				{Line: 2, Column: 10},
+				{Line: 2, Column: 11},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.source, func(t *testing.T) {
 			l := New(strings.NewReader(tt.source))
-			for _, expect := range tt.tokens {
+			for i, expect := range tt.tokens {
 				actual := l.Next()
+				if tt.positions != nil {
+					assert.Equal(t, tt.positions[i], actual.Pos)
+				}
+				actual.Pos = token.Position{}
 				assert.Equal(t, expect, actual)
 			}
 		})
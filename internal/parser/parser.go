@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/siyul-park/minijs/internal/ast"
 	"github.com/siyul-park/minijs/internal/lexer"
@@ -11,10 +12,13 @@ import (
 )
 
 type Parser struct {
-	lexer  *lexer.Lexer
-	tokens [3]token.Token
+	lexer *lexer.Lexer
+	// tokens holds the previous and current tokens, followed by the ones read ahead so far.
+	tokens []token.Token
 	prefix map[token.Type]func() (ast.Expression, error)
 	infix  map[token.Type]func(ast.Expression) (ast.Expression, error)
+	// grouped is the last expression enclosed in parentheses.
+	grouped ast.Expression
 }
 
 const (
@@ -26,45 +30,111 @@ const (
 const (
 	_ int = iota
 	LOWEST
+	SEQUENCE
 	ASSIGN
+	CONDITIONAL
+	OR
+	AND
+	BIT_OR
+	BIT_XOR
+	BIT_AND
+	EQUALITY
+	RELATIONAL
+	SHIFT
 	SUM
 	PRODUCT
 	MODULUS
+	EXPONENT
 	PREFIX
+	POSTFIX
 	CALL
 	HIGHEST
 )
 
 var precedences = map[token.Type]int{
-	token.ASSIGN:     ASSIGN,
-	token.PLUS:       SUM,
-	token.MINUS:      SUM,
-	token.MULTIPLY:   PRODUCT,
-	token.DIVIDE:     PRODUCT,
-	token.MODULUS:    MODULUS,
-	token.OPEN_PAREN: MODULUS,
+	token.COMMA:                         SEQUENCE,
+	token.ASSIGN:                        ASSIGN,
+	token.PLUS_ASSIGN:                   ASSIGN,
+	token.MINUS_ASSIGN:                  ASSIGN,
+	token.MULTIPLY_ASSIGN:               ASSIGN,
+	token.DIVIDE_ASSIGN:                 ASSIGN,
+	token.MODULUS_ASSIGN:                ASSIGN,
+	token.EXPONENT_ASSIGN:               ASSIGN,
+	token.LEFT_SHIFT_ARITHMETIC_ASSIGN:  ASSIGN,
+	token.RIGHT_SHIFT_ARITHMETIC_ASSIGN: ASSIGN,
+	token.RIGHT_SHIFT_LOGICAL_ASSIGN:    ASSIGN,
+	token.BIT_AND_ASSIGN:                ASSIGN,
+	token.BIT_OR_ASSIGN:                 ASSIGN,
+	token.BIT_XOR_ASSIGN:                ASSIGN,
+	token.AND_ASSIGN:                    ASSIGN,
+	token.OR_ASSIGN:                     ASSIGN,
+	token.NULLISH_ASSIGN:                ASSIGN,
+	token.QUESTION:                      CONDITIONAL,
+	token.OR:                            OR,
+	token.NULLISH:                       OR,
+	token.AND:                           AND,
+	token.BIT_OR:                        BIT_OR,
+	token.BIT_XOR:                       BIT_XOR,
+	token.BIT_AND:                       BIT_AND,
+	token.EQUAL:                         EQUALITY,
+	token.NOT_EQUAL:                     EQUALITY,
+	token.IDENTITY_EQUAL:                EQUALITY,
+	token.IDENTITY_NOT_EQUAL:            EQUALITY,
+	token.LESS_THAN:                     RELATIONAL,
+	token.GREATER_THAN:                  RELATIONAL,
+	token.LESS_THAN_OR_EQUAL:            RELATIONAL,
+	token.GREATER_THAN_OR_EQUAL:         RELATIONAL,
+	token.INSTANCEOF:                    RELATIONAL,
+	token.LEFT_SHIFT_ARITHMETIC:         SHIFT,
+	token.RIGHT_SHIFT_ARITHMETIC:        SHIFT,
+	token.RIGHT_SHIFT_LOGICAL:           SHIFT,
+	token.PLUS:                          SUM,
+	token.MINUS:                         SUM,
+	token.MULTIPLY:                      PRODUCT,
+	token.DIVIDE:                        PRODUCT,
+	token.MODULUS:                       MODULUS,
+	token.EXPONENT:                      EXPONENT,
+	token.PLUS_PLUS:                     POSTFIX,
+	token.MINUS_MINUS:                   POSTFIX,
+	token.DOT:                           CALL,
+	token.OPEN_BRACKET:                  CALL,
+	token.OPEN_PAREN:                    CALL,
+	token.OPTIONAL_CHAIN:                CALL,
 }
 
 func New(lexer *lexer.Lexer) *Parser {
 	p := &Parser{
 		lexer: lexer,
-		tokens: [3]token.Token{
+		tokens: []token.Token{
 			token.New(token.EOF, ""),
 			lexer.Next(),
-			lexer.Next(),
 		},
 	}
 	p.prefix = map[token.Type]func() (ast.Expression, error){
-		token.NULL:       p.nullLiteral,
-		token.UNDEFINED:  p.undefinedLiteral,
-		token.TRUE:       p.boolLiteral,
-		token.FALSE:      p.boolLiteral,
-		token.NUMBER:     p.numberLiteral,
-		token.STRING:     p.stringLiteral,
-		token.IDENTIFIER: p.identifierLiteral,
-		token.PLUS:       p.prefixExpression,
-		token.MINUS:      p.prefixExpression,
-		token.OPEN_PAREN: p.groupedExpression,
+		token.NULL:         p.nullLiteral,
+		token.UNDEFINED:    p.undefinedLiteral,
+		token.TRUE:         p.boolLiteral,
+		token.FALSE:        p.boolLiteral,
+		token.NUMBER:       p.numberLiteral,
+		token.STRING:       p.stringLiteral,
+		token.IDENTIFIER:   p.identifierLiteral,
+		token.PLUS:         p.prefixExpression,
+		token.MINUS:        p.prefixExpression,
+		token.NOT:          p.prefixExpression,
+		token.BIT_NOT:      p.prefixExpression,
+		token.TYPEOF:       p.prefixExpression,
+		token.VOID:         p.prefixExpression,
+		token.DELETE:       p.prefixExpression,
+		token.PLUS_PLUS:    p.updateExpression,
+		token.MINUS_MINUS:  p.updateExpression,
+		token.OPEN_PAREN:   p.groupedExpression,
+		token.FUNCTION:     p.functionExpression,
+		token.THIS:         p.thisExpression,
+		token.SUPER:        p.superExpression,
+		token.CLASS:        p.classExpression,
+		token.NEW:          p.newExpression,
+		token.OPEN_BRACE:   p.objectLiteral,
+		token.OPEN_BRACKET: p.arrayLiteral,
 	}
 	p.infix = map[token.Type]func(ast.Expression) (ast.Expression, error){
 		token.PLUS:     p.infixExpression,
@@ -72,7 +142,57 @@ func New(lexer *lexer.Lexer) *Parser {
 		token.MULTIPLY: p.infixExpression,
 		token.DIVIDE:   p.infixExpression,
 		token.MODULUS:  p.infixExpression,
-		token.ASSIGN:   p.assignmentExpression,
+		token.EXPONENT: p.exponentExpression,
+
+		token.EQUAL:                 p.infixExpression,
+		token.NOT_EQUAL:             p.infixExpression,
+		token.IDENTITY_EQUAL:        p.infixExpression,
+		token.IDENTITY_NOT_EQUAL:    p.infixExpression,
+		token.LESS_THAN:             p.infixExpression,
+		token.GREATER_THAN:          p.infixExpression,
+		token.LESS_THAN_OR_EQUAL:    p.infixExpression,
+		token.GREATER_THAN_OR_EQUAL: p.infixExpression,
+		token.INSTANCEOF:            p.infixExpression,
+
+		token.BIT_AND:                p.infixExpression,
+		token.BIT_OR:                 p.infixExpression,
+		token.BIT_XOR:                p.infixExpression,
+		token.LEFT_SHIFT_ARITHMETIC:  p.infixExpression,
+		token.RIGHT_SHIFT_ARITHMETIC: p.infixExpression,
+		token.RIGHT_SHIFT_LOGICAL:    p.infixExpression,
+
+		token.AND:     p.logicalExpression,
+		token.OR:      p.logicalExpression,
+		token.NULLISH: p.logicalExpression,
+
+		token.QUESTION: p.conditionalExpression,
+
+		token.COMMA: p.sequenceExpression,
+
+		token.ASSIGN:                        p.assignmentExpression,
+		token.PLUS_ASSIGN:                   p.assignmentExpression,
+		token.MINUS_ASSIGN:                  p.assignmentExpression,
+		token.MULTIPLY_ASSIGN:               p.assignmentExpression,
+		token.DIVIDE_ASSIGN:                 p.assignmentExpression,
+		token.MODULUS_ASSIGN:                p.assignmentExpression,
+		token.EXPONENT_ASSIGN:               p.assignmentExpression,
+		token.LEFT_SHIFT_ARITHMETIC_ASSIGN:  p.assignmentExpression,
+		token.RIGHT_SHIFT_ARITHMETIC_ASSIGN: p.assignmentExpression,
+		token.RIGHT_SHIFT_LOGICAL_ASSIGN:    p.assignmentExpression,
+		token.BIT_AND_ASSIGN:                p.assignmentExpression,
+		token.BIT_OR_ASSIGN:                 p.assignmentExpression,
+		token.BIT_XOR_ASSIGN:                p.assignmentExpression,
+		token.AND_ASSIGN:                    p.assignmentExpression,
+		token.OR_ASSIGN:                     p.assignmentExpression,
+		token.NULLISH_ASSIGN:                p.assignmentExpression,
+
+		token.PLUS_PLUS:   p.postfixUpdateExpression,
+		token.MINUS_MINUS: p.postfixUpdateExpression,
+
+		token.DOT:            p.memberExpression,
+		token.OPEN_BRACKET:   p.memberExpression,
+		token.OPEN_PAREN:     p.callExpression,
+		token.OPTIONAL_CHAIN: p.chainExpression,
 	}
 	return p
 }
@@ -95,8 +215,37 @@ func (p *Parser) statement() (ast.Statement, error) {
 		return p.emptyStatement()
 	case token.OPEN_BRACE:
 		return p.blockStatement()
-	case token.VAR:
+	case token.VAR, token.LET, token.CONST:
 		return p.variableStatement()
+	case token.IF:
+		return p.ifStatement()
+	case token.WHILE:
+		return p.whileStatement()
+	case token.DO:
+		return p.doWhileStatement()
+	case token.FOR:
+		return p.forStatement()
+	case token.SWITCH:
+		return p.switchStatement()
+	case token.BREAK:
+		return p.breakStatement()
+	case token.CONTINUE:
+		return p.continueStatement()
+	case token.RETURN:
+		return p.returnStatement()
+	case token.THROW:
+		return p.throwStatement()
+	case token.TRY:
+		return p.tryStatement()
+	case token.FUNCTION:
+		return p.functionDeclaration()
+	case token.CLASS:
+		return p.classDeclaration()
+	case token.IDENTIFIER:
+		if p.peek(NEXT).Type == token.COLON {
+			return p.labeledStatement()
+		}
+		return p.expressionStatement()
 	default:
 		return p.expressionStatement()
 	}
@@ -186,11 +335,167 @@ func (p *Parser) numberLiteral() (ast.Expression, error) {
 }
 
 func (p *Parser) identifierLiteral() (ast.Expression, error) {
+	if p.peek(NEXT).Type == token.ARROW {
+		return p.arrowFunctionExpression()
+	}
 	curr := p.peek(CURR)
 	p.pop()
 	return ast.NewIdentifierLiteral(curr, curr.Literal), nil
 }
 
+// arrayLiteral parses an array literal, where elisions leave holes.
+func (p *Parser) arrayLiteral() (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	var elements []ast.Expression
+	for p.peek(CURR).Type != token.CLOSE_BRACKET {
+		if p.peek(CURR).Type == token.COMMA {
+			p.pop()
+			elements = append(elements, nil)
+			continue
+		}
+
+		spread := p.peek(CURR)
+		if spread.Type == token.ELLIPSIS {
+			p.pop()
+		}
+		elem, err := p.expression(SEQUENCE)
+		if err != nil {
+			return nil, err
+		}
+		if spread.Type == token.ELLIPSIS {
+			elem = ast.NewSpreadElement(spread, elem)
+		}
+		elements = append(elements, elem)
+
+		if p.peek(CURR).Type != token.COMMA {
+			break
+		}
+		p.pop()
+	}
+
+	if err := p.expect(token.CLOSE_BRACKET); err != nil {
+		return nil, err
+	}
+	return ast.NewArrayLiteral(curr, elements...), nil
+}
+
+func (p *Parser) objectLiteral() (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	var properties []*ast.Property
+	for p.peek(CURR).Type != token.CLOSE_BRACE {
+		prop, err := p.property()
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, prop)
+
+		if p.peek(CURR).Type != token.COMMA {
+			break
+		}
+		p.pop()
+	}
+
+	if err := p.expect(token.CLOSE_BRACE); err != nil {
+		return nil, err
+	}
+	return ast.NewObjectLiteral(curr, properties...), nil
+}
+
+// property parses a property of an object literal, which is either a key and value pair, a shorthand, a method or
+// an accessor.
+func (p *Parser) property() (*ast.Property, error) {
+	kind := ast.INIT
+	if curr := p.peek(CURR); curr.Type == token.IDENTIFIER && (curr.Literal == ast.GET || curr.Literal == ast.SET) && p.key(p.peek(NEXT)) {
+		p.pop()
+		kind = curr.Literal
+	}
+
+	curr := p.peek(CURR)
+
+	var key ast.Expression
+	var err error
+	computed := false
+	switch {
+	case curr.Type == token.OPEN_BRACKET:
+		p.pop()
+		if key, err = p.expression(SEQUENCE); err != nil {
+			return nil, err
+		}
+		if err := p.expect(token.CLOSE_BRACKET); err != nil {
+			return nil, err
+		}
+		computed = true
+	case curr.Type == token.STRING:
+		key, err = p.stringLiteral()
+	case curr.Type == token.NUMBER:
+		key, err = p.numberLiteral()
+	case p.name(curr):
+		p.pop()
+		key = ast.NewIdentifierLiteral(curr, curr.Literal)
+	default:
+		return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.IDENTIFIER, curr.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if kind != ast.INIT && p.peek(CURR).Type != token.OPEN_PAREN {
+		return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.OPEN_PAREN, p.peek(CURR).Type)
+	}
+
+	switch p.peek(CURR).Type {
+	case token.COLON:
+		p.pop()
+		value, err := p.expression(SEQUENCE)
+		if err != nil {
+			return nil, err
+		}
+		return ast.NewProperty(key, value, kind, computed, false, false), nil
+	case token.OPEN_PAREN:
+		parameters, err := p.parameters()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.accessor(kind, parameters); err != nil {
+			return nil, err
+		}
+		if p.peek(CURR).Type != token.OPEN_BRACE {
+			return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.OPEN_BRACE, p.peek(CURR).Type)
+		}
+		body, err := p.blockStatement()
+		if err != nil {
+			return nil, err
+		}
+		value := ast.NewFunctionExpression(token.New(token.FUNCTION, string(token.FUNCTION)), nil, parameters, body.(*ast.BlockStatement))
+		return ast.NewProperty(key, value, kind, computed, false, true), nil
+	default:
+		if curr.Type != token.IDENTIFIER {
+			return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.COLON, p.peek(CURR).Type)
+		}
+		return ast.NewProperty(key, key, kind, false, true, false), nil
+	}
+}
+
+// accessor checks the parameters of a method of the kind, where getters take none and setters take exactly one.
+func (p *Parser) accessor(kind string, parameters []ast.Expression) error {
+	switch {
+	case kind == ast.GET && len(parameters) != 0:
+		return fmt.Errorf("getter must not have any formal parameters")
+	case kind == ast.SET && len(parameters) != 1:
+		return fmt.Errorf("setter must have exactly one formal parameter")
+	case kind == ast.SET:
+		if _, ok := parameters[0].(*ast.RestElement); ok {
+			return fmt.Errorf("setter function argument must not be a rest parameter")
+		}
+	default:
+	}
+	return nil
+}
+
 func (p *Parser) emptyStatement() (ast.Statement, error) {
 	p.pop()
 	return ast.NewEmptyStatement(), nil
@@ -227,17 +532,25 @@ func (p *Parser) variableStatement() (ast.Statement, error) {
 	curr := p.peek(CURR)
 	p.pop()
 
-	var expressions []*ast.AssignmentExpression
+	var expressions []ast.Expression
 	for {
-		exp, err := p.expression(LOWEST)
+		exp, err := p.expression(SEQUENCE)
 		if err != nil {
 			return nil, err
 		}
-		right, ok := exp.(*ast.AssignmentExpression)
-		if !ok {
+		switch exp := exp.(type) {
+		case *ast.IdentifierLiteral:
+			if curr.Type == token.CONST {
+				return nil, fmt.Errorf("missing initializer in const declaration")
+			}
+		case *ast.AssignmentExpression:
+			if _, ok := exp.Left.(*ast.IdentifierLiteral); !ok || exp.Token.Type != token.ASSIGN {
+				return nil, fmt.Errorf("expected assignment expressions, got %s", exp.Token.Literal)
+			}
+		default:
 			return nil, fmt.Errorf("expected assignment expressions, got %s", p.peek(CURR).Literal)
 		}
-		expressions = append(expressions, right)
+		expressions = append(expressions, exp)
 
 		if p.peek(CURR).Type != token.COMMA {
 			break
@@ -247,71 +560,991 @@ func (p *Parser) variableStatement() (ast.Statement, error) {
 	return ast.NewVariableStatement(curr, expressions...), nil
 }
 
-func (p *Parser) prefixExpression() (ast.Expression, error) {
+func (p *Parser) ifStatement() (ast.Statement, error) {
 	curr := p.peek(CURR)
 	p.pop()
 
-	right, err := p.expression(PREFIX)
+	if err := p.expect(token.OPEN_PAREN); err != nil {
+		return nil, err
+	}
+	condition, err := p.expression(LOWEST)
 	if err != nil {
 		return nil, err
 	}
-	return ast.NewPrefixExpression(curr, right), nil
+	if err := p.expect(token.CLOSE_PAREN); err != nil {
+		return nil, err
+	}
+
+	consequence, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	var alternative ast.Statement
+	if p.peek(CURR).Type == token.ELSE {
+		p.pop()
+		alternative, err = p.statement()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ast.NewIfStatement(curr, condition, consequence, alternative), nil
 }
 
-func (p *Parser) infixExpression(left ast.Expression) (ast.Expression, error) {
+func (p *Parser) whileStatement() (ast.Statement, error) {
 	curr := p.peek(CURR)
-	precedence := p.precedence(CURR)
 	p.pop()
 
-	right, err := p.expression(precedence)
+	if err := p.expect(token.OPEN_PAREN); err != nil {
+		return nil, err
+	}
+	condition, err := p.expression(LOWEST)
 	if err != nil {
 		return nil, err
 	}
-	return ast.NewInfixExpression(curr, left, right), nil
+	if err := p.expect(token.CLOSE_PAREN); err != nil {
+		return nil, err
+	}
+
+	body, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewWhileStatement(curr, condition, body), nil
 }
 
-func (p *Parser) groupedExpression() (ast.Expression, error) {
+func (p *Parser) doWhileStatement() (ast.Statement, error) {
+	curr := p.peek(CURR)
 	p.pop()
-	n, err := p.expression(LOWEST)
+
+	body, err := p.statement()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(token.WHILE); err != nil {
+		return nil, err
+	}
+	if err := p.expect(token.OPEN_PAREN); err != nil {
+		return nil, err
+	}
+	condition, err := p.expression(LOWEST)
 	if err != nil {
 		return nil, err
 	}
+	if err := p.expect(token.CLOSE_PAREN); err != nil {
+		return nil, err
+	}
+	if p.peek(CURR).Type == token.SEMICOLON {
+		p.pop()
+	}
+	return ast.NewDoWhileStatement(curr, body, condition), nil
+}
+
+func (p *Parser) forStatement() (ast.Statement, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	if err := p.expect(token.OPEN_PAREN); err != nil {
+		return nil, err
+	}
+
+	var init ast.Statement
+	switch p.peek(CURR).Type {
+	case token.SEMICOLON:
+	case token.VAR, token.LET, token.CONST:
+		stmt, err := p.variableStatement()
+		if err != nil {
+			return nil, err
+		}
+		init = stmt
+	default:
+		exp, err := p.expression(LOWEST)
+		if err != nil {
+			return nil, err
+		}
+		init = ast.NewExpressionStatement(exp)
+	}
+	if err := p.expect(token.SEMICOLON); err != nil {
+		return nil, err
+	}
+
+	var condition ast.Expression
+	if p.peek(CURR).Type != token.SEMICOLON {
+		exp, err := p.expression(LOWEST)
+		if err != nil {
+			return nil, err
+		}
+		condition = exp
+	}
+	if err := p.expect(token.SEMICOLON); err != nil {
+		return nil, err
+	}
 
+	var update ast.Expression
 	if p.peek(CURR).Type != token.CLOSE_PAREN {
-		return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.CLOSE_PAREN, p.peek(CURR).Type)
+		exp, err := p.expression(LOWEST)
+		if err != nil {
+			return nil, err
+		}
+		update = exp
+	}
+	if err := p.expect(token.CLOSE_PAREN); err != nil {
+		return nil, err
+	}
+
+	body, err := p.statement()
+	if err != nil {
+		return nil, err
 	}
+	return ast.NewForStatement(curr, init, condition, update, body), nil
+}
+
+func (p *Parser) switchStatement() (ast.Statement, error) {
+	curr := p.peek(CURR)
 	p.pop()
-	return n, nil
+
+	if err := p.expect(token.OPEN_PAREN); err != nil {
+		return nil, err
+	}
+	discriminant, err := p.expression(LOWEST)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(token.CLOSE_PAREN); err != nil {
+		return nil, err
+	}
+	if err := p.expect(token.OPEN_BRACE); err != nil {
+		return nil, err
+	}
+
+	var cases []*ast.SwitchCase
+	defaults := false
+	for p.peek(CURR).Type != token.CLOSE_BRACE {
+		clause := p.peek(CURR)
+		p.pop()
+
+		var test ast.Expression
+		switch clause.Type {
+		case token.CASE:
+			test, err = p.expression(LOWEST)
+			if err != nil {
+				return nil, err
+			}
+		case token.DEFAULT:
+			if defaults {
+				return nil, fmt.Errorf("more than one default clause in switch statement")
+			}
+			defaults = true
+		default:
+			return nil, fmt.Errorf("expected next token to be %s or %s, got %s instead", token.CASE, token.DEFAULT, clause.Type)
+		}
+		if err := p.expect(token.COLON); err != nil {
+			return nil, err
+		}
+
+		var consequent []ast.Statement
+		for typ := p.peek(CURR).Type; typ != token.CASE && typ != token.DEFAULT && typ != token.CLOSE_BRACE; typ = p.peek(CURR).Type {
+			stmt, err := p.statement()
+			if err != nil {
+				return nil, err
+			}
+			consequent = append(consequent, stmt)
+		}
+		cases = append(cases, ast.NewSwitchCase(clause, test, consequent...))
+	}
+	p.pop()
+
+	return ast.NewSwitchStatement(curr, discriminant, cases...), nil
 }
 
-func (p *Parser) assignmentExpression(left ast.Expression) (ast.Expression, error) {
+func (p *Parser) labeledStatement() (ast.Statement, error) {
 	curr := p.peek(CURR)
 	p.pop()
+	p.pop()
 
-	right, err := p.expression(LOWEST)
+	body, err := p.statement()
 	if err != nil {
 		return nil, err
 	}
-	return ast.NewAssignmentExpression(curr, left, right), nil
+	return ast.NewLabeledStatement(ast.NewIdentifierLiteral(curr, curr.Literal), body), nil
 }
 
-func (p *Parser) precedence(i int) int {
-	peek := p.peek(i)
-	if precedence, ok := precedences[peek.Type]; ok {
-		return precedence
+func (p *Parser) breakStatement() (ast.Statement, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	label := p.label()
+	if p.peek(CURR).Type == token.SEMICOLON {
+		p.pop()
 	}
-	return LOWEST
+	return ast.NewBreakStatement(curr, label), nil
+}
+
+func (p *Parser) continueStatement() (ast.Statement, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	label := p.label()
+	if p.peek(CURR).Type == token.SEMICOLON {
+		p.pop()
+	}
+	return ast.NewContinueStatement(curr, label), nil
+}
+
+func (p *Parser) returnStatement() (ast.Statement, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	var argument ast.Expression
+	switch p.peek(CURR).Type {
+	case token.SEMICOLON, token.CLOSE_BRACE, token.EOF:
+	default:
+		exp, err := p.expression(LOWEST)
+		if err != nil {
+			return nil, err
+		}
+		argument = exp
+	}
+	if p.peek(CURR).Type == token.SEMICOLON {
+		p.pop()
+	}
+	return ast.NewReturnStatement(curr, argument), nil
+}
+
+func (p *Parser) throwStatement() (ast.Statement, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	argument, err := p.expression(LOWEST)
+	if err != nil {
+		return nil, err
+	}
+	if p.peek(CURR).Type == token.SEMICOLON {
+		p.pop()
+	}
+	return ast.NewThrowStatement(curr, argument), nil
+}
+
+func (p *Parser) tryStatement() (ast.Statement, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	block, err := p.block()
+	if err != nil {
+		return nil, err
+	}
+
+	var handler *ast.CatchClause
+	if clause := p.peek(CURR); clause.Type == token.CATCH {
+		p.pop()
+
+		// The binding of the exception is optional.
+		var param *ast.IdentifierLiteral
+		if p.peek(CURR).Type == token.OPEN_PAREN {
+			p.pop()
+			ident := p.peek(CURR)
+			if ident.Type != token.IDENTIFIER {
+				return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.IDENTIFIER, ident.Type)
+			}
+			p.pop()
+			param = ast.NewIdentifierLiteral(ident, ident.Literal)
+			if err := p.expect(token.CLOSE_PAREN); err != nil {
+				return nil, err
+			}
+		}
+
+		body, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		handler = ast.NewCatchClause(clause, param, body)
+	}
+
+	var finalizer *ast.BlockStatement
+	if p.peek(CURR).Type == token.FINALLY {
+		p.pop()
+		if finalizer, err = p.block(); err != nil {
+			return nil, err
+		}
+	}
+
+	if handler == nil && finalizer == nil {
+		return nil, fmt.Errorf("missing catch or finally after try")
+	}
+	return ast.NewTryStatement(curr, block, handler, finalizer), nil
+}
+
+// block parses a block statement required by the syntax, such as the body of a try statement.
+func (p *Parser) block() (*ast.BlockStatement, error) {
+	if p.peek(CURR).Type != token.OPEN_BRACE {
+		return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.OPEN_BRACE, p.peek(CURR).Type)
+	}
+	stmt, err := p.blockStatement()
+	if err != nil {
+		return nil, err
+	}
+	return stmt.(*ast.BlockStatement), nil
+}
+
+func (p *Parser) functionDeclaration() (ast.Statement, error) {
+	exp, err := p.functionExpression()
+	if err != nil {
+		return nil, err
+	}
+	function := exp.(*ast.FunctionExpression)
+	if function.Name == nil {
+		return nil, fmt.Errorf("function statements require a function name")
+	}
+	return ast.NewFunctionDeclaration(function), nil
+}
+
+func (p *Parser) classDeclaration() (ast.Statement, error) {
+	exp, err := p.classExpression()
+	if err != nil {
+		return nil, err
+	}
+	class := exp.(*ast.ClassExpression)
+	if class.Name == nil {
+		return nil, fmt.Errorf("class statements require a class name")
+	}
+	return ast.NewClassDeclaration(class), nil
+}
+
+func (p *Parser) label() *ast.IdentifierLiteral {
+	curr := p.peek(CURR)
+	if curr.Type != token.IDENTIFIER {
+		return nil
+	}
+	p.pop()
+	return ast.NewIdentifierLiteral(curr, curr.Literal)
+}
+
+func (p *Parser) prefixExpression() (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	right, err := p.expression(PREFIX)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewPrefixExpression(curr, right), nil
+}
+
+func (p *Parser) functionExpression() (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	var name *ast.IdentifierLiteral
+	if p.peek(CURR).Type == token.IDENTIFIER {
+		name = ast.NewIdentifierLiteral(p.peek(CURR), p.peek(CURR).Literal)
+		p.pop()
+	}
+
+	parameters, err := p.parameters()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek(CURR).Type != token.OPEN_BRACE {
+		return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.OPEN_BRACE, p.peek(CURR).Type)
+	}
+	body, err := p.blockStatement()
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewFunctionExpression(curr, name, parameters, body.(*ast.BlockStatement)), nil
+}
+
+// arrowFunctionExpression parses an arrow function, whose parameters are a single identifier or a parenthesized list.
+func (p *Parser) arrowFunctionExpression() (ast.Expression, error) {
+	var parameters []ast.Expression
+	if curr := p.peek(CURR); curr.Type == token.IDENTIFIER {
+		p.pop()
+		parameters = append(parameters, ast.NewIdentifierLiteral(curr, curr.Literal))
+	} else {
+		var err error
+		if parameters, err = p.parameters(); err != nil {
+			return nil, err
+		}
+	}
+
+	curr := p.peek(CURR)
+	if err := p.expect(token.ARROW); err != nil {
+		return nil, err
+	}
+
+	var body ast.Node
+	var err error
+	if p.peek(CURR).Type == token.OPEN_BRACE {
+		body, err = p.blockStatement()
+	} else {
+		body, err = p.expression(SEQUENCE)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewArrowFunctionExpression(curr, parameters, body), nil
+}
+
+func (p *Parser) thisExpression() (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+	return ast.NewThisExpression(curr), nil
+}
+
+func (p *Parser) superExpression() (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	switch p.peek(CURR).Type {
+	case token.OPEN_PAREN, token.DOT, token.OPEN_BRACKET:
+		return ast.NewSuperExpression(curr), nil
+	default:
+		return nil, fmt.Errorf("'super' keyword unexpected here")
+	}
+}
+
+func (p *Parser) classExpression() (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	var name *ast.IdentifierLiteral
+	if p.peek(CURR).Type == token.IDENTIFIER {
+		name = ast.NewIdentifierLiteral(p.peek(CURR), p.peek(CURR).Literal)
+		p.pop()
+	}
+
+	var superClass ast.Expression
+	if p.peek(CURR).Type == token.EXTENDS {
+		p.pop()
+		var err error
+		if superClass, err = p.expression(POSTFIX); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.expect(token.OPEN_BRACE); err != nil {
+		return nil, err
+	}
+	var members []ast.Node
+	constructor := false
+	for p.peek(CURR).Type != token.CLOSE_BRACE {
+		if p.peek(CURR).Type == token.SEMICOLON {
+			p.pop()
+			continue
+		}
+		member, err := p.member()
+		if err != nil {
+			return nil, err
+		}
+		if method, ok := member.(*ast.MethodDefinition); ok && method.Kind == ast.CONSTRUCTOR {
+			if constructor {
+				return nil, fmt.Errorf("a class may only have one constructor")
+			}
+			constructor = true
+		}
+		members = append(members, member)
+	}
+	p.pop()
+	return ast.NewClassExpression(curr, name, superClass, members), nil
+}
+
+// member parses a member of a class, which is a method, an accessor or a field, any of them possibly static.
+// The static, get and set modifiers are names of members of their own unless a key follows them.
+func (p *Parser) member() (ast.Node, error) {
+	static := false
+	if curr := p.peek(CURR); curr.Type == token.IDENTIFIER && curr.Literal == "static" && p.key(p.peek(NEXT)) {
+		p.pop()
+		static = true
+	}
+	kind := ast.METHOD
+	if curr := p.peek(CURR); curr.Type == token.IDENTIFIER && (curr.Literal == ast.GET || curr.Literal == ast.SET) && p.key(p.peek(NEXT)) {
+		p.pop()
+		kind = curr.Literal
+	}
+
+	curr := p.peek(CURR)
+	var key ast.Expression
+	var err error
+	computed := false
+	switch {
+	case curr.Type == token.OPEN_BRACKET:
+		p.pop()
+		if key, err = p.expression(SEQUENCE); err != nil {
+			return nil, err
+		}
+		if err := p.expect(token.CLOSE_BRACKET); err != nil {
+			return nil, err
+		}
+		computed = true
+	case curr.Type == token.STRING:
+		key, err = p.stringLiteral()
+	case curr.Type == token.NUMBER:
+		key, err = p.numberLiteral()
+	case curr.Type == token.PRIVATE_IDENTIFIER:
+		if curr.Literal == "#constructor" {
+			return nil, fmt.Errorf("classes may not have a private field named '#constructor'")
+		}
+		p.pop()
+		key = ast.NewPrivateIdentifier(curr, curr.Literal)
+	case p.name(curr):
+		p.pop()
+		key = ast.NewIdentifierLiteral(curr, curr.Literal)
+	default:
+		return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.IDENTIFIER, curr.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	constructor := false
+	switch key := key.(type) {
+	case *ast.IdentifierLiteral:
+		constructor = !computed && key.Value == ast.CONSTRUCTOR
+	case *ast.StringLiteral:
+		constructor = key.Value == ast.CONSTRUCTOR
+	default:
+	}
+
+	if p.peek(CURR).Type != token.OPEN_PAREN {
+		if kind != ast.METHOD {
+			return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.OPEN_PAREN, p.peek(CURR).Type)
+		}
+		if constructor {
+			return nil, fmt.Errorf("classes may not have a field named 'constructor'")
+		}
+		var value ast.Expression
+		if p.peek(CURR).Type == token.ASSIGN {
+			p.pop()
+			if value, err = p.expression(SEQUENCE); err != nil {
+				return nil, err
+			}
+		}
+		if p.peek(CURR).Type == token.SEMICOLON {
+			p.pop()
+		}
+		return ast.NewPropertyDefinition(key, value, computed, static), nil
+	}
+
+	parameters, err := p.parameters()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.accessor(kind, parameters); err != nil {
+		return nil, err
+	}
+	if p.peek(CURR).Type != token.OPEN_BRACE {
+		return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.OPEN_BRACE, p.peek(CURR).Type)
+	}
+	body, err := p.blockStatement()
+	if err != nil {
+		return nil, err
+	}
+	if constructor && !static {
+		if kind != ast.METHOD {
+			return nil, fmt.Errorf("class constructor may not be an accessor")
+		}
+		kind = ast.CONSTRUCTOR
+	}
+	value := ast.NewFunctionExpression(token.New(token.FUNCTION, string(token.FUNCTION)), nil, parameters, body.(*ast.BlockStatement))
+	return ast.NewMethodDefinition(key, value, kind, computed, static), nil
+}
+
+// newExpression parses the constructor along with the member accesses on it, leaving the calls on the result of new.
+// The arguments may be omitted, and a nested new takes the first arguments following it.
+func (p *Parser) newExpression() (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	prefix, ok := p.prefix[p.peek(CURR).Type]
+	if !ok {
+		return nil, fmt.Errorf("no prefix expression function for %s", p.peek(CURR).Type)
+	}
+	callee, err := prefix()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek(CURR).Type == token.DOT || p.peek(CURR).Type == token.OPEN_BRACKET {
+		if callee, err = p.memberExpression(callee); err != nil {
+			return nil, err
+		}
+	}
+
+	var arguments []ast.Expression
+	if p.peek(CURR).Type == token.OPEN_PAREN {
+		if arguments, err = p.arguments(); err != nil {
+			return nil, err
+		}
+	}
+	return ast.NewNewExpression(curr, callee, arguments), nil
+}
+
+func (p *Parser) updateExpression() (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	operand, err := p.expression(PREFIX)
+	if err != nil {
+		return nil, err
+	}
+	if !p.reference(operand) {
+		return nil, fmt.Errorf("invalid left-hand side expression in prefix operation")
+	}
+	return ast.NewUpdateExpression(curr, operand, true), nil
+}
+
+func (p *Parser) postfixUpdateExpression(operand ast.Expression) (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	if !p.reference(operand) {
+		return nil, fmt.Errorf("invalid left-hand side expression in postfix operation")
+	}
+	return ast.NewUpdateExpression(curr, operand, false), nil
+}
+
+func (p *Parser) infixExpression(left ast.Expression) (ast.Expression, error) {
+	curr := p.peek(CURR)
+	precedence := p.precedence(CURR)
+	p.pop()
+
+	right, err := p.expression(precedence)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewInfixExpression(curr, left, right), nil
+}
+
+func (p *Parser) exponentExpression(left ast.Expression) (ast.Expression, error) {
+	if _, ok := left.(*ast.PrefixExpression); ok && left != p.grouped {
+		return nil, fmt.Errorf("unary operator used immediately before exponentiation expression")
+	}
+
+	curr := p.peek(CURR)
+	p.pop()
+
+	// Exponentiation is right-associative.
+	right, err := p.expression(EXPONENT - 1)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewInfixExpression(curr, left, right), nil
+}
+
+func (p *Parser) logicalExpression(left ast.Expression) (ast.Expression, error) {
+	curr := p.peek(CURR)
+	precedence := p.precedence(CURR)
+	if p.mixed(curr.Type, left) {
+		return nil, fmt.Errorf("cannot mix '??' with '&&' or '||' without parentheses")
+	}
+	p.pop()
+
+	right, err := p.expression(precedence)
+	if err != nil {
+		return nil, err
+	}
+	if p.mixed(curr.Type, right) {
+		return nil, fmt.Errorf("cannot mix '??' with '&&' or '||' without parentheses")
+	}
+	return ast.NewLogicalExpression(curr, left, right), nil
+}
+
+func (p *Parser) memberExpression(object ast.Expression) (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	optional := curr.Type == token.OPTIONAL_CHAIN
+	if curr.Type == token.OPEN_BRACKET || (optional && p.peek(CURR).Type == token.OPEN_BRACKET) {
+		if optional {
+			p.pop()
+		}
+		property, err := p.expression(LOWEST)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(token.CLOSE_BRACKET); err != nil {
+			return nil, err
+		}
+		return ast.NewMemberExpression(curr, object, property, true, optional), nil
+	}
+
+	name := p.peek(CURR)
+	if name.Type == token.PRIVATE_IDENTIFIER {
+		p.pop()
+		return ast.NewMemberExpression(curr, object, ast.NewPrivateIdentifier(name, name.Literal), false, optional), nil
+	}
+	if !p.name(name) {
+		return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.IDENTIFIER, name.Type)
+	}
+	p.pop()
+	property := ast.NewIdentifierLiteral(name, name.Literal)
+	return ast.NewMemberExpression(curr, object, property, false, optional), nil
+}
+
+func (p *Parser) callExpression(callee ast.Expression) (ast.Expression, error) {
+	curr := p.peek(CURR)
+	optional := curr.Type == token.OPTIONAL_CHAIN
+	if optional {
+		p.pop()
+	}
+
+	arguments, err := p.arguments()
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewCallExpression(curr, callee, arguments, optional), nil
+}
+
+// chainExpression parses the rest of the member and call chain following an optional link.
+func (p *Parser) chainExpression(left ast.Expression) (ast.Expression, error) {
+	exp := left
+	for {
+		var err error
+		switch p.peek(CURR).Type {
+		case token.OPTIONAL_CHAIN:
+			if p.peek(NEXT).Type == token.OPEN_PAREN {
+				exp, err = p.callExpression(exp)
+			} else {
+				exp, err = p.memberExpression(exp)
+			}
+		case token.DOT, token.OPEN_BRACKET:
+			exp, err = p.memberExpression(exp)
+		case token.OPEN_PAREN:
+			exp, err = p.callExpression(exp)
+		default:
+			return ast.NewChainExpression(exp), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *Parser) conditionalExpression(test ast.Expression) (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	consequent, err := p.expression(SEQUENCE)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(token.COLON); err != nil {
+		return nil, err
+	}
+	alternative, err := p.expression(SEQUENCE)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewConditionalExpression(curr, test, consequent, alternative), nil
+}
+
+func (p *Parser) sequenceExpression(left ast.Expression) (ast.Expression, error) {
+	expressions := []ast.Expression{left}
+	for p.peek(CURR).Type == token.COMMA {
+		p.pop()
+
+		exp, err := p.expression(SEQUENCE)
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, exp)
+	}
+	return ast.NewSequenceExpression(expressions...), nil
+}
+
+func (p *Parser) groupedExpression() (ast.Expression, error) {
+	if p.arrow() {
+		return p.arrowFunctionExpression()
+	}
+
+	p.pop()
+	n, err := p.expression(LOWEST)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(token.CLOSE_PAREN); err != nil {
+		return nil, err
+	}
+	p.grouped = n
+	return n, nil
+}
+
+func (p *Parser) assignmentExpression(left ast.Expression) (ast.Expression, error) {
+	curr := p.peek(CURR)
+	p.pop()
+
+	right, err := p.expression(SEQUENCE)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewAssignmentExpression(curr, left, right), nil
+}
+
+func (p *Parser) arguments() ([]ast.Expression, error) {
+	if err := p.expect(token.OPEN_PAREN); err != nil {
+		return nil, err
+	}
+
+	var arguments []ast.Expression
+	for p.peek(CURR).Type != token.CLOSE_PAREN {
+		spread := p.peek(CURR)
+		if spread.Type == token.ELLIPSIS {
+			p.pop()
+		}
+		arg, err := p.expression(SEQUENCE)
+		if err != nil {
+			return nil, err
+		}
+		if spread.Type == token.ELLIPSIS {
+			arg = ast.NewSpreadElement(spread, arg)
+		}
+		arguments = append(arguments, arg)
+
+		if p.peek(CURR).Type != token.COMMA {
+			break
+		}
+		p.pop()
+	}
+
+	if err := p.expect(token.CLOSE_PAREN); err != nil {
+		return nil, err
+	}
+	return arguments, nil
+}
+
+func (p *Parser) parameters() ([]ast.Expression, error) {
+	if err := p.expect(token.OPEN_PAREN); err != nil {
+		return nil, err
+	}
+
+	var parameters []ast.Expression
+	for p.peek(CURR).Type != token.CLOSE_PAREN {
+		rest := p.peek(CURR)
+		if rest.Type == token.ELLIPSIS {
+			p.pop()
+		}
+
+		curr := p.peek(CURR)
+		if curr.Type != token.IDENTIFIER {
+			return nil, fmt.Errorf("expected next token to be %s, got %s instead", token.IDENTIFIER, curr.Type)
+		}
+		p.pop()
+		var param ast.Expression = ast.NewIdentifierLiteral(curr, curr.Literal)
+
+		switch {
+		case rest.Type == token.ELLIPSIS:
+			param = ast.NewRestElement(rest, param.(*ast.IdentifierLiteral))
+			if p.peek(CURR).Type != token.CLOSE_PAREN {
+				return nil, fmt.Errorf("rest parameter must be last formal parameter")
+			}
+		case p.peek(CURR).Type == token.ASSIGN:
+			assign := p.peek(CURR)
+			p.pop()
+			value, err := p.expression(SEQUENCE)
+			if err != nil {
+				return nil, err
+			}
+			param = ast.NewAssignmentExpression(assign, param, value)
+		default:
+		}
+		parameters = append(parameters, param)
+
+		if p.peek(CURR).Type != token.COMMA {
+			break
+		}
+		p.pop()
+	}
+
+	if err := p.expect(token.CLOSE_PAREN); err != nil {
+		return nil, err
+	}
+	return parameters, nil
+}
+
+// arrow reports whether the parenthesis at the current token encloses the parameters of an arrow function,
+// which is only known once the matching parenthesis is followed by '=>'.
+func (p *Parser) arrow() bool {
+	depth := 0
+	for i := CURR; ; i++ {
+		switch p.peek(i).Type {
+		case token.OPEN_PAREN:
+			depth++
+		case token.CLOSE_PAREN:
+			depth--
+			if depth == 0 {
+				return p.peek(i+1).Type == token.ARROW
+			}
+		case token.EOF:
+			return false
+		default:
+		}
+	}
+}
+
+// mixed reports whether the operand mixes '??' with '&&' or '||' without parentheses.
+func (p *Parser) mixed(op token.Type, operand ast.Expression) bool {
+	exp, ok := operand.(*ast.LogicalExpression)
+	if !ok || exp == p.grouped {
+		return false
+	}
+	return (op == token.NULLISH) != (exp.Token.Type == token.NULLISH)
+}
+
+// reference reports whether the expression refers to a variable or a property, which can be assigned to.
+func (p *Parser) reference(exp ast.Expression) bool {
+	switch exp.(type) {
+	case *ast.IdentifierLiteral, *ast.MemberExpression:
+		return true
+	default:
+		return false
+	}
+}
+
+// key reports whether the token can start the key of a class member.
+func (p *Parser) key(tok token.Token) bool {
+	switch tok.Type {
+	case token.OPEN_BRACKET, token.STRING, token.NUMBER, token.PRIVATE_IDENTIFIER:
+		return true
+	default:
+		return p.name(tok)
+	}
+}
+
+// name reports whether the token can be used as a property name, reserved words included.
+func (p *Parser) name(tok token.Token) bool {
+	if tok.Type == token.IDENTIFIER {
+		return true
+	}
+	return token.TypeOf(tok.Literal) == tok.Type && unicode.IsLetter([]rune(tok.Literal)[0])
+}
+
+func (p *Parser) precedence(i int) int {
+	peek := p.peek(i)
+	if precedence, ok := precedences[peek.Type]; ok {
+		return precedence
+	}
+	return LOWEST
+}
+
+func (p *Parser) expect(typ token.Type) error {
+	if p.peek(CURR).Type != typ {
+		return fmt.Errorf("expected next token to be %s, got %s instead", typ, p.peek(CURR).Type)
+	}
+	p.pop()
+	return nil
 }
 
+// peek returns the token at the offset, reading ahead as far as needed.
 func (p *Parser) peek(i int) token.Token {
-	if i >= len(p.tokens) {
-		return token.New(token.EOF, "")
+	for len(p.tokens) <= i {
+		p.tokens = append(p.tokens, p.lexer.Next())
 	}
 	return p.tokens[i]
 }
 
 func (p *Parser) pop() {
-	p.tokens[PREV] = p.tokens[CURR]
-	p.tokens[CURR] = p.tokens[NEXT]
-	p.tokens[NEXT] = p.lexer.Next()
+	p.peek(NEXT)
+	p.tokens = append(p.tokens[:PREV], p.tokens[CURR:]...)
 }
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -185,6 +186,739 @@ func TestParser_Parse(t *testing.T) {
 				),
 			),
 		},
+		{
+			"a < b == c >= d",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewInfixExpression(
+						token.New(token.EQUAL, "=="),
+						ast.NewInfixExpression(
+							token.New(token.LESS_THAN, "<"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						),
+						ast.NewInfixExpression(
+							token.New(token.GREATER_THAN_OR_EQUAL, ">="),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "d"), "d"),
+						),
+					),
+				),
+			),
+		},
+		{
+			"a + b !== c",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewInfixExpression(
+						token.New(token.IDENTITY_NOT_EQUAL, "!=="),
+						ast.NewInfixExpression(
+							token.New(token.PLUS, "+"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+					),
+				),
+			),
+		},
+		{
+			"typeof a + void b",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewInfixExpression(
+						token.New(token.PLUS, "+"),
+						ast.NewPrefixExpression(
+							token.New(token.TYPEOF, "typeof"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						),
+						ast.NewPrefixExpression(
+							token.New(token.VOID, "void"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						),
+					),
+				),
+			),
+		},
+		{
+			"a = 1, b = 2",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewSequenceExpression(
+						ast.NewAssignmentExpression(
+							token.New(token.ASSIGN, "="),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewNumberLiteral(token.New(token.NUMBER, "1"), 1),
+						),
+						ast.NewAssignmentExpression(
+							token.New(token.ASSIGN, "="),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+							ast.NewNumberLiteral(token.New(token.NUMBER, "2"), 2),
+						),
+					),
+				),
+			),
+		},
+		{
+			"a ? b : c, d",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewSequenceExpression(
+						ast.NewConditionalExpression(
+							token.New(token.QUESTION, "?"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+						),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "d"), "d"),
+					),
+				),
+			),
+		},
+		{
+			"a ?? b ?? c",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewLogicalExpression(
+						token.New(token.NULLISH, "??"),
+						ast.NewLogicalExpression(
+							token.New(token.NULLISH, "??"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+					),
+				),
+			),
+		},
+		{
+			"a?.b.c(d)",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewChainExpression(
+						ast.NewCallExpression(
+							token.New(token.OPEN_PAREN, "("),
+							ast.NewMemberExpression(
+								token.New(token.DOT, "."),
+								ast.NewMemberExpression(
+									token.New(token.OPTIONAL_CHAIN, "?."),
+									ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+									ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+									false,
+									true,
+								),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+								false,
+								false,
+							),
+							[]ast.Expression{ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "d"), "d")},
+							false,
+						),
+					),
+				),
+			),
+		},
+		{
+			"a?.[b]?.()",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewChainExpression(
+						ast.NewCallExpression(
+							token.New(token.OPTIONAL_CHAIN, "?."),
+							ast.NewMemberExpression(
+								token.New(token.OPTIONAL_CHAIN, "?."),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+								true,
+								true,
+							),
+							nil,
+							true,
+						),
+					),
+				),
+			),
+		},
+		{
+			"(a?.b).c",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewMemberExpression(
+						token.New(token.DOT, "."),
+						ast.NewChainExpression(
+							ast.NewMemberExpression(
+								token.New(token.OPTIONAL_CHAIN, "?."),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+								false,
+								true,
+							),
+						),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+						false,
+						false,
+					),
+				),
+			),
+		},
+		{
+			`({a: 1, "b": c, 2: d, [e]: f, g, h(i) { return i; }, if: j})`,
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewObjectLiteral(
+						token.New(token.OPEN_BRACE, "{"),
+						ast.NewProperty(
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewNumberLiteral(token.New(token.NUMBER, "1"), 1),
+							ast.INIT,
+							false,
+							false,
+							false,
+						),
+						ast.NewProperty(
+							ast.NewStringLiteral(token.New(token.STRING, "b"), "b"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+							ast.INIT,
+							false,
+							false,
+							false,
+						),
+						ast.NewProperty(
+							ast.NewNumberLiteral(token.New(token.NUMBER, "2"), 2),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "d"), "d"),
+							ast.INIT,
+							false,
+							false,
+							false,
+						),
+						ast.NewProperty(
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "e"), "e"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "f"), "f"),
+							ast.INIT,
+							true,
+							false,
+							false,
+						),
+						ast.NewProperty(
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "g"), "g"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "g"), "g"),
+							ast.INIT,
+							false,
+							true,
+							false,
+						),
+						ast.NewProperty(
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "h"), "h"),
+							ast.NewFunctionExpression(
+								token.New(token.FUNCTION, "function"),
+								nil,
+								[]ast.Expression{
+									ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "i"), "i"),
+								},
+								ast.NewBlockStatement(
+									ast.NewReturnStatement(
+										token.New(token.RETURN, "return"),
+										ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "i"), "i"),
+									),
+								),
+							),
+							ast.INIT,
+							false,
+							false,
+							true,
+						),
+						ast.NewProperty(
+							ast.NewIdentifierLiteral(token.New(token.IF, "if"), "if"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "j"), "j"),
+							ast.INIT,
+							false,
+							false,
+							false,
+						),
+					),
+				),
+			),
+		},
+		{
+			`({get a() {}, set a(b) {}, get: 1})`,
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewObjectLiteral(
+						token.New(token.OPEN_BRACE, "{"),
+						ast.NewProperty(
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewFunctionExpression(
+								token.New(token.FUNCTION, "function"),
+								nil,
+								nil,
+								ast.NewBlockStatement(),
+							),
+							ast.GET,
+							false,
+							false,
+							true,
+						),
+						ast.NewProperty(
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewFunctionExpression(
+								token.New(token.FUNCTION, "function"),
+								nil,
+								[]ast.Expression{
+									ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+								},
+								ast.NewBlockStatement(),
+							),
+							ast.SET,
+							false,
+							false,
+							true,
+						),
+						ast.NewProperty(
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "get"), "get"),
+							ast.NewNumberLiteral(token.New(token.NUMBER, "1"), 1),
+							ast.INIT,
+							false,
+							false,
+							false,
+						),
+					),
+				),
+			),
+		},
+		{
+			"[1, , ...a, ]",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewArrayLiteral(
+						token.New(token.OPEN_BRACKET, "["),
+						ast.NewNumberLiteral(token.New(token.NUMBER, "1"), 1),
+						nil,
+						ast.NewSpreadElement(
+							token.New(token.ELLIPSIS, "..."),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						),
+					),
+				),
+			),
+		},
+		{
+			"[,]",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewArrayLiteral(token.New(token.OPEN_BRACKET, "["), nil),
+				),
+			),
+		},
+		{
+			"a.b++",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewUpdateExpression(
+						token.New(token.PLUS_PLUS, "++"),
+						ast.NewMemberExpression(
+							token.New(token.DOT, "."),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+							false,
+							false,
+						),
+						false,
+					),
+				),
+			),
+		},
+		{
+			"delete a",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewPrefixExpression(
+						token.New(token.DELETE, "delete"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+					),
+				),
+			),
+		},
+		{
+			"!a || b && c",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewLogicalExpression(
+						token.New(token.OR, "||"),
+						ast.NewPrefixExpression(
+							token.New(token.NOT, "!"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						),
+						ast.NewLogicalExpression(
+							token.New(token.AND, "&&"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+						),
+					),
+				),
+			),
+		},
+		{
+			"a && b == c",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewLogicalExpression(
+						token.New(token.AND, "&&"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewInfixExpression(
+							token.New(token.EQUAL, "=="),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+						),
+					),
+				),
+			),
+		},
+		{
+			"if (a) b; else { c }",
+			ast.NewProgram(
+				ast.NewIfStatement(
+					token.New(token.IF, "if"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+					ast.NewExpressionStatement(
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+					),
+					ast.NewBlockStatement(
+						ast.NewExpressionStatement(
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+						),
+					),
+				),
+			),
+		},
+		{
+			"if (a) if (b) c; else d",
+			ast.NewProgram(
+				ast.NewIfStatement(
+					token.New(token.IF, "if"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+					ast.NewIfStatement(
+						token.New(token.IF, "if"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						ast.NewExpressionStatement(
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+						),
+						ast.NewExpressionStatement(
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "d"), "d"),
+						),
+					),
+					nil,
+				),
+			),
+		},
+		{
+			"a ? b : c ? d : e",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewConditionalExpression(
+						token.New(token.QUESTION, "?"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						ast.NewConditionalExpression(
+							token.New(token.QUESTION, "?"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "d"), "d"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "e"), "e"),
+						),
+					),
+				),
+			),
+		},
+		{
+			"a || b ? c : d = e",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewConditionalExpression(
+						token.New(token.QUESTION, "?"),
+						ast.NewLogicalExpression(
+							token.New(token.OR, "||"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+						ast.NewAssignmentExpression(
+							token.New(token.ASSIGN, "="),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "d"), "d"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "e"), "e"),
+						),
+					),
+				),
+			),
+		},
+		{
+			"a /= b %= c",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewAssignmentExpression(
+						token.New(token.DIVIDE_ASSIGN, "/="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewAssignmentExpression(
+							token.New(token.MODULUS_ASSIGN, "%="),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+						),
+					),
+				),
+			),
+		},
+		{
+			"-a++ + --b",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewInfixExpression(
+						token.New(token.PLUS, "+"),
+						ast.NewPrefixExpression(
+							token.New(token.MINUS, "-"),
+							ast.NewUpdateExpression(token.New(token.PLUS_PLUS, "++"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"), false),
+						),
+						ast.NewUpdateExpression(token.New(token.MINUS_MINUS, "--"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"), true),
+					),
+				),
+			),
+		},
+		{
+			"a | b ^ c & d << e",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewInfixExpression(
+						token.New(token.BIT_OR, "|"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewInfixExpression(
+							token.New(token.BIT_XOR, "^"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+							ast.NewInfixExpression(
+								token.New(token.BIT_AND, "&"),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+								ast.NewInfixExpression(
+									token.New(token.LEFT_SHIFT_ARITHMETIC, "<<"),
+									ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "d"), "d"),
+									ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "e"), "e"),
+								),
+							),
+						),
+					),
+				),
+			),
+		},
+		{
+			"a ** b ** c",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewInfixExpression(
+						token.New(token.EXPONENT, "**"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewInfixExpression(
+							token.New(token.EXPONENT, "**"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+						),
+					),
+				),
+			),
+		},
+		{
+			"(-a) ** -b * c",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewInfixExpression(
+						token.New(token.MULTIPLY, "*"),
+						ast.NewInfixExpression(
+							token.New(token.EXPONENT, "**"),
+							ast.NewPrefixExpression(token.New(token.MINUS, "-"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a")),
+							ast.NewPrefixExpression(token.New(token.MINUS, "-"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b")),
+						),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+					),
+				),
+			),
+		},
+		{
+			"a **= b",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewAssignmentExpression(
+						token.New(token.EXPONENT_ASSIGN, "**="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+					),
+				),
+			),
+		},
+		{
+			"~a >>> b",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewInfixExpression(
+						token.New(token.RIGHT_SHIFT_LOGICAL, ">>>"),
+						ast.NewPrefixExpression(token.New(token.BIT_NOT, "~"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a")),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+					),
+				),
+			),
+		},
+		{
+			"while (a) { break; }",
+			ast.NewProgram(
+				ast.NewWhileStatement(
+					token.New(token.WHILE, "while"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+					ast.NewBlockStatement(
+						ast.NewBreakStatement(token.New(token.BREAK, "break"), nil),
+					),
+				),
+			),
+		},
+		{
+			"do continue; while (a)",
+			ast.NewProgram(
+				ast.NewDoWhileStatement(
+					token.New(token.DO, "do"),
+					ast.NewContinueStatement(token.New(token.CONTINUE, "continue"), nil),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+				),
+			),
+		},
+		{
+			"for (var a = b; a < c; a = a + 1) d",
+			ast.NewProgram(
+				ast.NewForStatement(
+					token.New(token.FOR, "for"),
+					ast.NewVariableStatement(
+						token.New(token.VAR, "var"),
+						ast.NewAssignmentExpression(
+							token.New(token.ASSIGN, "="),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						),
+					),
+					ast.NewInfixExpression(
+						token.New(token.LESS_THAN, "<"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+					),
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewInfixExpression(
+							token.New(token.PLUS, "+"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewNumberLiteral(token.New(token.NUMBER, "1"), 1),
+						),
+					),
+					ast.NewExpressionStatement(ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "d"), "d")),
+				),
+			),
+		},
+		{
+			"a: while (b) { continue a; }",
+			ast.NewProgram(
+				ast.NewLabeledStatement(
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+					ast.NewWhileStatement(
+						token.New(token.WHILE, "while"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						ast.NewBlockStatement(
+							ast.NewContinueStatement(token.New(token.CONTINUE, "continue"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a")),
+						),
+					),
+				),
+			),
+		},
+		{
+			"a: { break a }",
+			ast.NewProgram(
+				ast.NewLabeledStatement(
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+					ast.NewBlockStatement(
+						ast.NewBreakStatement(token.New(token.BREAK, "break"), ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a")),
+					),
+				),
+			),
+		},
+		{
+			"throw a",
+			ast.NewProgram(
+				ast.NewThrowStatement(
+					token.New(token.THROW, "throw"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+				),
+			),
+		},
+		{
+			"try { a } catch (e) { b } finally { c }",
+			ast.NewProgram(
+				ast.NewTryStatement(
+					token.New(token.TRY, "try"),
+					ast.NewBlockStatement(
+						ast.NewExpressionStatement(ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a")),
+					),
+					ast.NewCatchClause(
+						token.New(token.CATCH, "catch"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "e"), "e"),
+						ast.NewBlockStatement(
+							ast.NewExpressionStatement(ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b")),
+						),
+					),
+					ast.NewBlockStatement(
+						ast.NewExpressionStatement(ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c")),
+					),
+				),
+			),
+		},
+		{
+			"try {} catch {}",
+			ast.NewProgram(
+				ast.NewTryStatement(
+					token.New(token.TRY, "try"),
+					ast.NewBlockStatement(),
+					ast.NewCatchClause(token.New(token.CATCH, "catch"), nil, ast.NewBlockStatement()),
+					nil,
+				),
+			),
+		},
+		{
+			"try {} finally {}",
+			ast.NewProgram(
+				ast.NewTryStatement(
+					token.New(token.TRY, "try"),
+					ast.NewBlockStatement(),
+					nil,
+					ast.NewBlockStatement(),
+				),
+			),
+		},
+		{
+			"switch (a) { case b: c; break; default: }",
+			ast.NewProgram(
+				ast.NewSwitchStatement(
+					token.New(token.SWITCH, "switch"),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+					ast.NewSwitchCase(
+						token.New(token.CASE, "case"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						ast.NewExpressionStatement(ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c")),
+						ast.NewBreakStatement(token.New(token.BREAK, "break"), nil),
+					),
+					ast.NewSwitchCase(token.New(token.DEFAULT, "default"), nil),
+				),
+			),
+		},
+		{
+			"for (;;) {}",
+			ast.NewProgram(
+				ast.NewForStatement(
+					token.New(token.FOR, "for"),
+					nil,
+					nil,
+					nil,
+					ast.NewBlockStatement(),
+				),
+			),
+		},
 		{
 			"a = b",
 			ast.NewProgram(
@@ -228,6 +962,335 @@ func TestParser_Parse(t *testing.T) {
 				),
 			),
 		},
+		{
+			"let a = 1, b",
+			ast.NewProgram(
+				ast.NewVariableStatement(
+					token.New(token.LET, "let"),
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewNumberLiteral(token.New(token.NUMBER, "1"), 1),
+					),
+					ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+				),
+			),
+		},
+		{
+			"const a = b",
+			ast.NewProgram(
+				ast.NewVariableStatement(
+					token.New(token.CONST, "const"),
+					ast.NewAssignmentExpression(
+						token.New(token.ASSIGN, "="),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+					),
+				),
+			),
+		},
+		{
+			"function f(a, b) { return a; }",
+			ast.NewProgram(
+				ast.NewFunctionDeclaration(
+					ast.NewFunctionExpression(
+						token.New(token.FUNCTION, "function"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "f"), "f"),
+						[]ast.Expression{
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						},
+						ast.NewBlockStatement(
+							ast.NewReturnStatement(
+								token.New(token.RETURN, "return"),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							),
+						),
+					),
+				),
+			),
+		},
+		{
+			"(function() { return })()",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewCallExpression(
+						token.New(token.OPEN_PAREN, "("),
+						ast.NewFunctionExpression(
+							token.New(token.FUNCTION, "function"),
+							nil,
+							nil,
+							ast.NewBlockStatement(
+								ast.NewReturnStatement(token.New(token.RETURN, "return"), nil),
+							),
+						),
+						nil,
+						false,
+					),
+				),
+			),
+		},
+		{
+			"(a, b) => a + b",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewArrowFunctionExpression(
+						token.New(token.ARROW, "=>"),
+						[]ast.Expression{
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						},
+						ast.NewInfixExpression(
+							token.New(token.PLUS, "+"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+						),
+					),
+				),
+			),
+		},
+		{
+			"x => { return this; }",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewArrowFunctionExpression(
+						token.New(token.ARROW, "=>"),
+						[]ast.Expression{
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "x"), "x"),
+						},
+						ast.NewBlockStatement(
+							ast.NewReturnStatement(
+								token.New(token.RETURN, "return"),
+								ast.NewThisExpression(token.New(token.THIS, "this")),
+							),
+						),
+					),
+				),
+			),
+		},
+		{
+			"new a.F(1)()",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewCallExpression(
+						token.New(token.OPEN_PAREN, "("),
+						ast.NewNewExpression(
+							token.New(token.NEW, "new"),
+							ast.NewMemberExpression(
+								token.New(token.DOT, "."),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "F"), "F"),
+								false,
+								false,
+							),
+							[]ast.Expression{
+								ast.NewNumberLiteral(token.New(token.NUMBER, "1"), 1),
+							},
+						),
+						nil,
+						false,
+					),
+				),
+			),
+		},
+		{
+			"new new F",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewNewExpression(
+						token.New(token.NEW, "new"),
+						ast.NewNewExpression(
+							token.New(token.NEW, "new"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "F"), "F"),
+							nil,
+						),
+						nil,
+					),
+				),
+			),
+		},
+		{
+			"a instanceof F === true",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewInfixExpression(
+						token.New(token.IDENTITY_EQUAL, "==="),
+						ast.NewInfixExpression(
+							token.New(token.INSTANCEOF, "instanceof"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "F"), "F"),
+						),
+						ast.NewBoolLiteral(token.New(token.TRUE, "true"), true),
+					),
+				),
+			),
+		},
+		{
+			"f(() => a, (a, b))",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewCallExpression(
+						token.New(token.OPEN_PAREN, "("),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "f"), "f"),
+						[]ast.Expression{
+							ast.NewArrowFunctionExpression(
+								token.New(token.ARROW, "=>"),
+								nil,
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							),
+							ast.NewSequenceExpression(
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+							),
+						},
+						false,
+					),
+				),
+			),
+		},
+		{
+			"function f(a, b = a * 2, ...c) {}",
+			ast.NewProgram(
+				ast.NewFunctionDeclaration(
+					ast.NewFunctionExpression(
+						token.New(token.FUNCTION, "function"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "f"), "f"),
+						[]ast.Expression{
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							ast.NewAssignmentExpression(
+								token.New(token.ASSIGN, "="),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "b"), "b"),
+								ast.NewInfixExpression(
+									token.New(token.MULTIPLY, "*"),
+									ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+									ast.NewNumberLiteral(token.New(token.NUMBER, "2"), 2),
+								),
+							),
+							ast.NewRestElement(
+								token.New(token.ELLIPSIS, "..."),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "c"), "c"),
+							),
+						},
+						ast.NewBlockStatement(),
+					),
+				),
+			),
+		},
+		{
+			"(...a) => f(1, ...a)",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewArrowFunctionExpression(
+						token.New(token.ARROW, "=>"),
+						[]ast.Expression{
+							ast.NewRestElement(
+								token.New(token.ELLIPSIS, "..."),
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+							),
+						},
+						ast.NewCallExpression(
+							token.New(token.OPEN_PAREN, "("),
+							ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "f"), "f"),
+							[]ast.Expression{
+								ast.NewNumberLiteral(token.New(token.NUMBER, "1"), 1),
+								ast.NewSpreadElement(
+									token.New(token.ELLIPSIS, "..."),
+									ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "a"), "a"),
+								),
+							},
+							false,
+						),
+					),
+				),
+			),
+		},
+		{
+			"class A extends B { constructor() { super(); } static get x() {} #y = 1; }",
+			ast.NewProgram(
+				ast.NewClassDeclaration(
+					ast.NewClassExpression(
+						token.New(token.CLASS, "class"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "A"), "A"),
+						ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "B"), "B"),
+						[]ast.Node{
+							ast.NewMethodDefinition(
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "constructor"), "constructor"),
+								ast.NewFunctionExpression(
+									token.New(token.FUNCTION, "function"),
+									nil,
+									nil,
+									ast.NewBlockStatement(
+										ast.NewExpressionStatement(
+											ast.NewCallExpression(
+												token.New(token.OPEN_PAREN, "("),
+												ast.NewSuperExpression(token.New(token.SUPER, "super")),
+												nil,
+												false,
+											),
+										),
+									),
+								),
+								ast.CONSTRUCTOR,
+								false,
+								false,
+							),
+							ast.NewMethodDefinition(
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "x"), "x"),
+								ast.NewFunctionExpression(
+									token.New(token.FUNCTION, "function"),
+									nil,
+									nil,
+									ast.NewBlockStatement(),
+								),
+								ast.GET,
+								false,
+								true,
+							),
+							ast.NewPropertyDefinition(
+								ast.NewPrivateIdentifier(token.New(token.PRIVATE_IDENTIFIER, "#y"), "#y"),
+								ast.NewNumberLiteral(token.New(token.NUMBER, "1"), 1),
+								false,
+								false,
+							),
+						},
+					),
+				),
+			),
+		},
+		{
+			"(class { static = 1 })",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewClassExpression(
+						token.New(token.CLASS, "class"),
+						nil,
+						nil,
+						[]ast.Node{
+							ast.NewPropertyDefinition(
+								ast.NewIdentifierLiteral(token.New(token.IDENTIFIER, "static"), "static"),
+								ast.NewNumberLiteral(token.New(token.NUMBER, "1"), 1),
+								false,
+								false,
+							),
+						},
+					),
+				),
+			),
+		},
+		{
+			"this.#x",
+			ast.NewProgram(
+				ast.NewExpressionStatement(
+					ast.NewMemberExpression(
+						token.New(token.DOT, "."),
+						ast.NewThisExpression(token.New(token.THIS, "this")),
+						ast.NewPrivateIdentifier(token.New(token.PRIVATE_IDENTIFIER, "#x"), "#x"),
+						false,
+						false,
+					),
+				),
+			),
+		},
 	}
 
 	for _, tt := range tests {
@@ -236,7 +1299,76 @@ func TestParser_Parse(t *testing.T) {
 			p := New(l)
 			program, err := p.Parse()
 			assert.NoError(t, err)
+			unposition(reflect.ValueOf(program))
 			assert.Equal(t, tt.program, program)
 		})
 	}
 }
+
+func TestParser_ParseError(t *testing.T) {
+	tests := []string{
+		"-a ** b",
+		"-(a) ** b",
+		"!a ** b",
+		"typeof a ** b",
+		"a ?? b || c",
+		"a && b ?? c",
+		"a ?? b && c",
+		"const a",
+		"let a += 1",
+		"function () {}",
+		"function f(1) {}",
+		"function f() return",
+		"(a + 1) => a",
+		"(a) =>",
+		"function f(...a, b) {}",
+		"({1})",
+		"({a b})",
+		"({get a(b) {}})",
+		"({set a() {}})",
+		"({set a(...b) {}})",
+		"({get a: 1})",
+		"class {}",
+		"class A { constructor() {} constructor() {} }",
+		"class A { get constructor() {} }",
+		"class A { #constructor }",
+		"super",
+		"try {}",
+		"try {} catch () {}",
+		"try a; catch {}",
+	}
+
+	for _, source := range tests {
+		t.Run(source, func(t *testing.T) {
+			l := lexer.New(strings.NewReader(source))
+			p := New(l)
+			_, err := p.Parse()
+			assert.Error(t, err)
+		})
+	}
+}
+
+// unposition clears the positions of the tokens in the tree, which the expected trees leave out.
+func unposition(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			unposition(v.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			unposition(v.Index(i))
+		}
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(token.Position{}) {
+			v.Set(reflect.Zero(v.Type()))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				unposition(v.Field(i))
+			}
+		}
+	default:
+	}
+}
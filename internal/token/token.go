@@ -1,10 +1,20 @@
 package token
 
+import "strconv"
+
 type Type string
 
 type Token struct {
 	Type    Type
 	Literal string
+	Pos     Position
+}
+
+// Position locates a token in the source by its line and column, both counted from 1.
+// The zero position stands for tokens made up apart from any source.
+type Position struct {
+	Line   int
+	Column int
 }
 
 const (
@@ -14,6 +24,8 @@ const (
 	NUMBER     Type = "NUMBER"
 	STRING     Type = "STRING"
 	IDENTIFIER Type = "IDENTIFIER"
+	// PRIVATE_IDENTIFIER names a private member of a class, whose literal keeps the leading '#'.
+	PRIVATE_IDENTIFIER Type = "PRIVATE_IDENTIFIER"
 
 	NULL      Type = "null"
 	UNDEFINED Type = "undefined"
@@ -46,6 +58,11 @@ const (
 	DELETE     Type = "delete"
 	IN         Type = "in"
 	TRY        Type = "try"
+	LET        Type = "let"
+	CONST      Type = "const"
+	CLASS      Type = "class"
+	EXTENDS    Type = "extends"
+	SUPER      Type = "super"
 
 	OPEN_BRACKET                  Type = "["
 	CLOSE_BRACKET                 Type = "]"
@@ -56,9 +73,12 @@ const (
 	SEMICOLON                     Type = ";"
 	COMMA                         Type = ","
 	ASSIGN                        Type = "="
+	ARROW                         Type = "=>"
 	QUESTION                      Type = "?"
+	OPTIONAL_CHAIN                Type = "?."
 	COLON                         Type = ":"
 	DOT                           Type = "."
+	ELLIPSIS                      Type = "..."
 	PLUS                          Type = "+"
 	MINUS                         Type = "-"
 	PLUS_PLUS                     Type = "++"
@@ -68,6 +88,7 @@ const (
 	MULTIPLY                      Type = "*"
 	DIVIDE                        Type = "/"
 	MODULUS                       Type = "%"
+	EXPONENT                      Type = "**"
 	RIGHT_SHIFT_ARITHMETIC        Type = ">>"
 	LEFT_SHIFT_ARITHMETIC         Type = "<<"
 	RIGHT_SHIFT_LOGICAL           Type = ">>>"
@@ -81,11 +102,14 @@ const (
 	IDENTITY_NOT_EQUAL            Type = "!=="
 	BIT_AND                       Type = "&"
 	BIT_OR                        Type = "|"
+	BIT_XOR                       Type = "^"
 	AND                           Type = "&&"
 	OR                            Type = "||"
+	NULLISH                       Type = "??"
 	MULTIPLY_ASSIGN               Type = "*="
-	DIVIDE_ASSIGN                 Type = "%="
+	DIVIDE_ASSIGN                 Type = "/="
 	MODULUS_ASSIGN                Type = "%="
+	EXPONENT_ASSIGN               Type = "**="
 	PLUS_ASSIGN                   Type = "+="
 	MINUS_ASSIGN                  Type = "-="
 	LEFT_SHIFT_ARITHMETIC_ASSIGN  Type = "<<="
@@ -94,25 +118,29 @@ const (
 	BIT_AND_ASSIGN                Type = "&="
 	BIT_OR_ASSIGN                 Type = "|="
 	BIT_XOR_ASSIGN                Type = "^="
+	AND_ASSIGN                    Type = "&&="
+	OR_ASSIGN                     Type = "||="
+	NULLISH_ASSIGN                Type = "??="
 )
 
 var reserved = []Type{
 	NULL, UNDEFINED, TRUE, FALSE,
 	BREAK, DO, INSTANCEOF, TYPEOF, CASE, ELSE, NEW, VAR, CATCH,
 	FINALLY, RETURN, VOID, CONTINUE, FOR, SWITCH, WHILE, DEBUGGER,
-	FUNCTION, THIS, WITH, DEFAULT, IF, THROW, DELETE, IN, TRY,
+	FUNCTION, THIS, WITH, DEFAULT, IF, THROW, DELETE, IN, TRY, LET, CONST,
+	CLASS, EXTENDS, SUPER,
 	OPEN_BRACKET, CLOSE_BRACKET, OPEN_PAREN, CLOSE_PAREN,
-	OPEN_BRACE, CLOSE_BRACE, SEMICOLON, COMMA, ASSIGN, QUESTION,
-	COLON, DOT, PLUS, MINUS, PLUS_PLUS, MINUS_MINUS, BIT_NOT, NOT,
-	MULTIPLY, DIVIDE, MODULUS, RIGHT_SHIFT_ARITHMETIC,
+	OPEN_BRACE, CLOSE_BRACE, SEMICOLON, COMMA, ASSIGN, ARROW, QUESTION, OPTIONAL_CHAIN,
+	COLON, DOT, ELLIPSIS, PLUS, MINUS, PLUS_PLUS, MINUS_MINUS, BIT_NOT, NOT,
+	MULTIPLY, DIVIDE, MODULUS, EXPONENT, RIGHT_SHIFT_ARITHMETIC,
 	LEFT_SHIFT_ARITHMETIC, RIGHT_SHIFT_LOGICAL, LESS_THAN,
 	GREATER_THAN, LESS_THAN_OR_EQUAL, GREATER_THAN_OR_EQUAL,
 	EQUAL, NOT_EQUAL, IDENTITY_EQUAL, IDENTITY_NOT_EQUAL,
-	BIT_AND, BIT_OR, AND, OR, MULTIPLY_ASSIGN, DIVIDE_ASSIGN,
-	MODULUS_ASSIGN, PLUS_ASSIGN, MINUS_ASSIGN,
+	BIT_AND, BIT_OR, BIT_XOR, AND, OR, NULLISH, MULTIPLY_ASSIGN, DIVIDE_ASSIGN,
+	MODULUS_ASSIGN, EXPONENT_ASSIGN, PLUS_ASSIGN, MINUS_ASSIGN,
 	LEFT_SHIFT_ARITHMETIC_ASSIGN, RIGHT_SHIFT_ARITHMETIC_ASSIGN,
 	RIGHT_SHIFT_LOGICAL_ASSIGN, BIT_AND_ASSIGN, BIT_OR_ASSIGN,
-	BIT_XOR_ASSIGN,
+	BIT_XOR_ASSIGN, AND_ASSIGN, OR_ASSIGN, NULLISH_ASSIGN,
 }
 
 var types = map[string]Type{}
@@ -138,3 +166,11 @@ func New(typ Type, literal string) Token {
 func (t Token) String() string {
 	return t.Literal
 }
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+	return strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+}
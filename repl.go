@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/siyul-park/minijs/internal/ast"
 	"github.com/siyul-park/minijs/internal/bytecode"
 	"github.com/siyul-park/minijs/internal/compiler"
 	"github.com/siyul-park/minijs/internal/interpreter"
@@ -85,7 +86,7 @@ func (r *REPL) Start(reader io.Reader, writer io.Writer) error {
 			insts = append(insts, inst)
 			offset += size
 		}
-		if len(insts) > 0 {
+		if len(insts) > 0 && r.completion(program) {
 			if insts[len(insts)-1].Opcode() == bytecode.POP {
 				insts = insts[:len(insts)-1]
 			}
@@ -101,7 +102,11 @@ func (r *REPL) Start(reader io.Reader, writer io.Writer) error {
 			continue
 		}
 
-		if _, err := fmt.Fprintln(writer, i.Pop()); err != nil {
+		val := i.Pop()
+		if val == nil {
+			val = interpreter.Undefined{}
+		}
+		if _, err := fmt.Fprintln(writer, val); err != nil {
 			return err
 		}
 	}
@@ -109,6 +114,14 @@ func (r *REPL) Start(reader io.Reader, writer io.Writer) error {
 	return nil
 }
 
+func (r *REPL) completion(program *ast.Program) bool {
+	if len(program.Statements) == 0 {
+		return false
+	}
+	_, ok := program.Statements[len(program.Statements)-1].(*ast.ExpressionStatement)
+	return ok
+}
+
 func (r *REPL) error(writer io.Writer, err error) error {
 	_, err = fmt.Fprintln(writer, err)
 	return err
@@ -10,12 +10,192 @@ import (
 )
 
 func TestREPL_Start(t *testing.T) {
-	var output bytes.Buffer
-	input := bytes.NewReader([]byte(`"hello, " + "world"`))
+	tests := []struct {
+		input  string
+		output string
+	}{
+		{
+			input:  `"hello, " + "world"`,
+			output: "\"hello, world\"\n",
+		},
+		{
+			input:  "1; 2\nvar a = 3\nfunction f() {}\nif (a) { 4 }\na",
+			output: "2\nundefined\nundefined\nundefined\n3\n",
+		},
+		{
+			input:  "1 + (function () { throw 2 })()\nvar b = 3",
+			output: "Uncaught 2\nundefined\n",
+		},
+		{
+			input:  "function h(){ break }\nfunction k(){ return 5 } k()",
+			output: "illegal break statement\n5\n",
+		},
+		{
+			input:  "{ let s = 42; } foo",
+			output: "Uncaught ReferenceError: foo is not defined\n    at <anonymous> (1:17)\n",
+		},
+		{
+			input:  `try { throw "secret" } catch (e) {} e`,
+			output: "Uncaught ReferenceError: e is not defined\n    at <anonymous> (1:37)\n",
+		},
+		{
+			input:  "for (let i = 0; i < 3; i++) {} j",
+			output: "Uncaught ReferenceError: j is not defined\n    at <anonymous> (1:32)\n",
+		},
+		{
+			input:  "{ let n = 1; } typeof undeclared",
+			output: "\"undefined\"\n",
+		},
+		{
+			input:  "{ let t = 7; }\nvar z; z",
+			output: "undefined\nundefined\n",
+		},
+		{
+			input:  "{ let a = 1; g = 2 } { let b = 3; let c = 4 } g",
+			output: "2\n",
+		},
+		{
+			input:  "let z = (() => { throw 1 })()\nz",
+			output: "Uncaught 1\nUncaught ReferenceError: cannot access 'z' before initialization\n    at <anonymous> (1:1)\n",
+		},
+		{
+			input:  "function h(a = b, b = 1){ return a } h()",
+			output: "Uncaught ReferenceError: cannot access 'b' before initialization\n    at h (1:16)\n    at <anonymous> (1:39)\n",
+		},
+		{
+			input:  "function h(a = a){} h()",
+			output: "Uncaught ReferenceError: cannot access 'a' before initialization\n    at h (1:16)\n    at <anonymous> (1:22)\n",
+		},
+		{
+			input:  "function h(a, b = () => c, c = 2){ return b() } h(1)",
+			output: "2\n",
+		},
+		{
+			input:  "foo\nlet foo = 1; foo",
+			output: "Uncaught ReferenceError: foo is not defined\n    at <anonymous> (1:1)\n1\n",
+		},
+		{
+			input:  "function f() { return g }\nvar g = 1; f()",
+			output: "undefined\n1\n",
+		},
+		{
+			input:  "\"use strict\"; h = 1",
+			output: "Uncaught ReferenceError: h is not defined\n    at <anonymous> (1:17)\n",
+		},
+		{
+			input:  "var s = \"\uffff\"; [s < \"😀\", s > \"😀\"]",
+			output: "[ false, true ]\n",
+		},
+		{
+			input:  "function f() { return w } var r = f(); let w = 1; r",
+			output: "Uncaught ReferenceError: cannot access 'w' before initialization\n    at f (1:23)\n    at <anonymous> (1:36)\n",
+		},
+		{
+			input:  "var i = 2147483647; i++; var j = -2147483648; j--; [i, j, ++i, --j]",
+			output: "[ 2147483648, -2147483649, 2147483649, -2147483650 ]\n",
+		},
+		{
+			input:  "var a = 2147483647; a += 1; var b = 2147483647; b++; [a, b > 2147483647, b | 0, b + \"\"]",
+			output: "[ 2147483648, true, -2147483648, '2147483648' ]\n",
+		},
+		{
+			input:  "var a = 1, b = 0; a &&= 2; b &&= 2; [a, b]",
+			output: "[ 2, 0 ]\n",
+		},
+		{
+			input:  "var n = 0; function f() { n++; return 9 } var a = 1, b = 0; a ||= f(); b ||= f(); [a, b, n]",
+			output: "[ 1, 9, 1 ]\n",
+		},
+		{
+			input:  "var o = { x: 0, y: 1 }; [o.x &&= 5, o.y &&= 6, o.x ||= 7, o.y ||= 8, o.x, o.y]",
+			output: "[ 0, 6, 7, 6, 7, 6 ]\n",
+		},
+		{
+			input:  "var z = 0; [(-0) ** -1, 1 / -z, 1 / -null, -0 === 0, Object.is(-0, 0), Object.is(-0, -z)]",
+			output: "[ -Infinity, -Infinity, -Infinity, true, false, true ]\n",
+		},
+		{
+			input:  "var a = []; a.length = 4294967295; [...a]",
+			output: "Uncaught RangeError: invalid array length\n    at <anonymous> (1:36)\n",
+		},
+		{
+			input:  "function f() {} var a = []; a.length = 100000; f(...a)",
+			output: "Uncaught RangeError: too many arguments in function call\n    at <anonymous> (1:49)\n",
+		},
+		{
+			input:  "var a = [1, , 3]; a[5000] = 6; var b = [...a]; [b.length, b[1], b[2], b[4999], b[5000]]",
+			output: "[ 5001, undefined, 3, undefined, 6 ]\n",
+		},
+		{
+			input:  "function f() { return this } [f() === globalThis, this === globalThis, globalThis.Object === Object]",
+			output: "[ true, true, true ]\n",
+		},
+		{
+			input:  "function f() { \"use strict\"; return this } [f(), (() => this)() === globalThis]",
+			output: "[ undefined, true ]\n",
+		},
+		{
+			input:  "var v = 3; function g() { return this.v } g()",
+			output: "3\n",
+		},
+		{
+			input:  "function f() { this.count = 1 } f(); count",
+			output: "1\n",
+		},
+		{
+			input:  "globalThis.foo = 1; foo",
+			output: "1\n",
+		},
+		{
+			input:  "globalThis.bar = 2\nbar = bar + 1; globalThis.bar",
+			output: "2\n3\n",
+		},
+		{
+			input:  "class B extends Object { m() { return 1 } } var b = new B(); [b instanceof B, b.m(), Object(b) === b]",
+			output: "[ true, 1, true ]\n",
+		},
+		{
+			input:  "var o = { valueOf() { return 5 } }; [o + 1, o * 3, o < 3, o == 5]",
+			output: "[ 6, 15, false, true ]\n",
+		},
+		{
+			input:  "[({ toString() { return \"T\" } }) + \"\", \"caught: \" + new TypeError(\"bad\"), ({}) + \"\", [1, 2] + \"\"]",
+			output: "[ 'T', 'caught: TypeError: bad', '[object Object]', '1,2' ]\n",
+		},
+		{
+			input:  "({ valueOf() { return {} }, toString() { return {} } }) + 1",
+			output: "Uncaught TypeError: cannot convert object to primitive value\n    at <anonymous> (1:57)\n",
+		},
+		{
+			input:  "class A {} class B extends A { constructor() { this.x = 1; super() } } var n; try { new B() } catch (e) { n = e.name } n",
+			output: "\"ReferenceError\"\n",
+		},
+		{
+			input:  "class A {} class B extends A { constructor() {} } new B()",
+			output: "Uncaught ReferenceError: must call super constructor in derived class before accessing 'this' or returning from derived constructor\n    at <anonymous> (1:51)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var output bytes.Buffer
+			r := minijs.NewREPL("")
+
+			err := r.Start(bytes.NewReader([]byte(tt.input)), &output)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.output, output.String())
+		})
+	}
+}
 
-	r := minijs.NewREPL("")
+func TestREPL_StartIsolated(t *testing.T) {
+	var output bytes.Buffer
+	err := minijs.NewREPL("").Start(bytes.NewReader([]byte("Object.prototype.x = 1; Object.freeze(Object.getPrototypeOf([])); ({}).x")), &output)
+	assert.NoError(t, err)
+	assert.Equal(t, "1\n", output.String())
 
-	err := r.Start(input, &output)
+	output.Reset()
+	err = minijs.NewREPL("").Start(bytes.NewReader([]byte("[({}).x, Object.isFrozen(Object.getPrototypeOf([]))]")), &output)
 	assert.NoError(t, err)
-	assert.Equal(t, "\"hello, world\"\n", output.String())
+	assert.Equal(t, "[ undefined, false ]\n", output.String())
 }